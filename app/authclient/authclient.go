@@ -0,0 +1,56 @@
+// Package authclient verifies the access tokens issued by auth-service for other okocraft services.
+//
+// The access tokens can be verified either offline with the public keys published at /.well-known/jwks.json (NewJWKSVerifier),
+// or online with the introspection endpoint (NewIntrospectionVerifier), which also rejects tokens revoked before they expire.
+package authclient
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// ErrInvalidToken is returned when the access token is malformed, expired, revoked or not signed by auth-service.
+var ErrInvalidToken = errors.New("invalid access token")
+
+// Identity is the user authenticated by an access token.
+type Identity struct {
+	// UserUUID is the public UUID of the user, read from the sub claim.
+	UserUUID uuid.UUID
+	// TokenID is the jti of the access token.
+	TokenID   uuid.UUID
+	ExpiresAt time.Time
+	// Roles are the role names of the user when the access token was issued.
+	Roles []string
+	// Permissions are read from the space-delimited scope claim.
+	Permissions []string
+}
+
+func (i Identity) HasRole(role string) bool {
+	return slices.Contains(i.Roles, role)
+}
+
+func (i Identity) HasPermission(permission string) bool {
+	return slices.Contains(i.Permissions, permission)
+}
+
+type Verifier interface {
+	// Verify returns ErrInvalidToken if the access token is not valid.
+	Verify(ctx context.Context, accessToken string) (Identity, error)
+}
+
+type identityKey struct{}
+
+// WithIdentity returns the context that holds the identity. It is mainly for tests of handlers.
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the identity put by the middleware.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
@@ -0,0 +1,98 @@
+// Package authclienttest provides a fake auth-service issuer for testing services that use authclient.
+package authclienttest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/okocraft/auth-service/authclient"
+	"github.com/okocraft/auth-service/internal/signing"
+	"github.com/okocraft/authlib/jwtclaims"
+)
+
+// Issuer signs access tokens in the same way as auth-service and serves its JWKS.
+type Issuer struct {
+	signer signing.KeySigner
+	server *httptest.Server
+}
+
+// NewIssuer starts a JWKS server with a random Ed25519 key. The server is closed when the test finishes.
+func NewIssuer(t testing.TB) *Issuer {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := &Issuer{signer: signing.NewEd25519Signer(privateKey)}
+	issuer.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(issuer.signer.JWKS())
+	}))
+	t.Cleanup(issuer.server.Close)
+
+	return issuer
+}
+
+// JWKSURL returns the URL of the JWKS served by the issuer.
+func (i *Issuer) JWKSURL() string {
+	return i.server.URL + "/.well-known/jwks.json"
+}
+
+// Verifier returns the JWKS verifier that trusts the issuer.
+func (i *Issuer) Verifier() authclient.Verifier {
+	return authclient.NewJWKSVerifier(authclient.JWKSVerifierConfig{
+		JWKSURL:    i.JWKSURL(),
+		HTTPClient: i.server.Client(),
+	})
+}
+
+// MintAccessToken returns an access token for the user that expires after ttl.
+// A negative ttl can be used to mint an expired token, and uuid.Nil can be used to omit the sub claim.
+func (i *Issuer) MintAccessToken(t testing.TB, userUUID uuid.UUID, ttl time.Duration) string {
+	t.Helper()
+	return i.MintAccessTokenWithRoles(t, userUUID, ttl, nil, nil)
+}
+
+// MintAccessTokenWithRoles returns an access token that has the roles and the permissions.
+func (i *Issuer) MintAccessTokenWithRoles(t testing.TB, userUUID uuid.UUID, ttl time.Duration, roles []string, permissions []string) string {
+	t.Helper()
+
+	jti, err := uuid.NewV7()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if roles == nil {
+		roles = []string{}
+	}
+
+	now := time.Now()
+	claims := jwtclaims.AccessTokenClaims{
+		BaseClaims: jwtclaims.BaseClaims{
+			JTI:       jti,
+			NotBefore: now,
+			ExpiresAt: now.Add(ttl),
+		},
+	}.CreateJWTClaims().(jwt.MapClaims)
+	if !userUUID.IsNil() {
+		claims["sub"] = userUUID.String()
+	}
+	claims["roles"] = roles
+	claims["scope"] = strings.Join(permissions, " ")
+
+	token, err := i.signer.Sign(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
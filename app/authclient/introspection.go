@@ -0,0 +1,167 @@
+package authclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Siroshun09/serrors"
+	"github.com/gofrs/uuid/v5"
+)
+
+type IntrospectionVerifierConfig struct {
+	// IntrospectionURL is the URL of the introspection endpoint, e.g. https://auth.example.com/auth/introspect
+	IntrospectionURL string
+	// APIKey is the service API key sent as X-API-Key.
+	APIKey     string
+	HTTPClient *http.Client
+	// CacheDuration is how long an active result is reused. Revocations are noticed after at most this duration.
+	// Zero disables the cache.
+	CacheDuration time.Duration
+}
+
+// NewIntrospectionVerifier creates a Verifier that asks auth-service whether the access token is active.
+func NewIntrospectionVerifier(cfg IntrospectionVerifierConfig) Verifier {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &introspectionVerifier{
+		introspectionURL: cfg.IntrospectionURL,
+		apiKey:           cfg.APIKey,
+		client:           client,
+		cacheDuration:    cfg.CacheDuration,
+		cache:            map[[sha256.Size]byte]cachedIdentity{},
+	}
+}
+
+type introspectionVerifier struct {
+	introspectionURL string
+	apiKey           string
+	client           *http.Client
+	cacheDuration    time.Duration
+
+	mu    sync.Mutex
+	cache map[[sha256.Size]byte]cachedIdentity
+}
+
+type cachedIdentity struct {
+	identity  Identity
+	expiresAt time.Time
+}
+
+type introspectionResponse struct {
+	Active bool     `json:"active"`
+	Sub    string   `json:"sub"`
+	JTI    string   `json:"jti"`
+	Exp    int64    `json:"exp"`
+	Scope  string   `json:"scope"`
+	Roles  []string `json:"roles"`
+}
+
+func (v *introspectionVerifier) Verify(ctx context.Context, accessToken string) (Identity, error) {
+	// the raw token is not kept in memory
+	cacheKey := sha256.Sum256([]byte(accessToken))
+	now := time.Now()
+
+	if identity, ok := v.getCached(cacheKey, now); ok {
+		return identity, nil
+	}
+
+	identity, err := v.introspect(ctx, accessToken)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	if v.cacheDuration > 0 {
+		expiresAt := now.Add(v.cacheDuration)
+		if identity.ExpiresAt.Before(expiresAt) {
+			expiresAt = identity.ExpiresAt
+		}
+		v.putCache(cacheKey, cachedIdentity{identity: identity, expiresAt: expiresAt}, now)
+	}
+
+	return identity, nil
+}
+
+func (v *introspectionVerifier) getCached(key [sha256.Size]byte, now time.Time) (Identity, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	cached, ok := v.cache[key]
+	if !ok || !now.Before(cached.expiresAt) {
+		return Identity{}, false
+	}
+	return cached.identity, true
+}
+
+func (v *introspectionVerifier) putCache(key [sha256.Size]byte, value cachedIdentity, now time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	// drop expired entries here instead of running a background goroutine
+	for k, cached := range v.cache {
+		if !now.Before(cached.expiresAt) {
+			delete(v.cache, k)
+		}
+	}
+	v.cache[key] = value
+}
+
+func (v *introspectionVerifier) introspect(ctx context.Context, accessToken string) (Identity, error) {
+	form := url.Values{}
+	form.Set("token", accessToken)
+	form.Set("token_type_hint", "access_token")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.introspectionURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Identity{}, serrors.WithStackTrace(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-API-Key", v.apiKey)
+
+	res, err := v.client.Do(req)
+	if err != nil {
+		return Identity{}, serrors.WithStackTrace(err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return Identity{}, serrors.Errorf("unexpected introspection response status: %d", res.StatusCode)
+	}
+
+	var body introspectionResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return Identity{}, serrors.WithStackTrace(err)
+	}
+
+	if !body.Active {
+		return Identity{}, serrors.WithStackTrace(ErrInvalidToken)
+	}
+
+	userUUID, err := uuid.FromString(body.Sub)
+	if err != nil {
+		return Identity{}, serrors.WithStackTrace(fmt.Errorf("invalid sub in introspection response: %w", err))
+	}
+
+	tokenID, err := uuid.FromString(body.JTI)
+	if err != nil {
+		return Identity{}, serrors.WithStackTrace(fmt.Errorf("invalid jti in introspection response: %w", err))
+	}
+
+	return Identity{
+		UserUUID:    userUUID,
+		TokenID:     tokenID,
+		ExpiresAt:   time.Unix(body.Exp, 0),
+		Roles:       body.Roles,
+		Permissions: strings.Fields(body.Scope),
+	}, nil
+}
@@ -0,0 +1,57 @@
+package authclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/okocraft/auth-service/authclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntrospectionVerifier_Verify(t *testing.T) {
+	userUUID := uuid.Must(uuid.NewV4())
+	jti := uuid.Must(uuid.NewV7())
+	exp := time.Now().Add(time.Minute).Truncate(time.Second)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.Equal(t, "api-key", r.Header.Get("X-API-Key"))
+		require.NoError(t, r.ParseForm())
+
+		res := map[string]any{"active": false}
+		if r.PostForm.Get("token") == "active-token" {
+			res = map[string]any{"active": true, "sub": userUUID.String(), "jti": jti.String(), "exp": exp.Unix(), "scope": "panel:read", "roles": []string{"staff"}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	t.Cleanup(server.Close)
+
+	verifier := authclient.NewIntrospectionVerifier(authclient.IntrospectionVerifierConfig{
+		IntrospectionURL: server.URL,
+		APIKey:           "api-key",
+		HTTPClient:       server.Client(),
+		CacheDuration:    time.Minute,
+	})
+
+	for range 2 {
+		identity, err := verifier.Verify(context.Background(), "active-token")
+		require.NoError(t, err)
+		assert.Equal(t, authclient.Identity{UserUUID: userUUID, TokenID: jti, ExpiresAt: exp, Roles: []string{"staff"}, Permissions: []string{"panel:read"}}, identity)
+	}
+	assert.Equal(t, int32(1), calls.Load(), "the active result should be cached")
+
+	for range 2 {
+		_, err := verifier.Verify(context.Background(), "inactive-token")
+		assert.ErrorIs(t, err, authclient.ErrInvalidToken)
+	}
+	assert.Equal(t, int32(3), calls.Load(), "inactive results should not be cached")
+}
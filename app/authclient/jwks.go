@@ -0,0 +1,120 @@
+package authclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Siroshun09/serrors"
+	"github.com/gofrs/uuid/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/okocraft/auth-service/internal/oidc"
+)
+
+type JWKSVerifierConfig struct {
+	// JWKSURL is the URL of the JWKS endpoint, e.g. https://auth.example.com/.well-known/jwks.json
+	JWKSURL    string
+	HTTPClient *http.Client
+	// CacheDuration is used when the JWKS response does not have a max-age directive. Defaults to 5 minutes.
+	CacheDuration time.Duration
+	// MinRefreshInterval limits how often an unknown kid can trigger refetching the JWKS. Defaults to 1 minute.
+	MinRefreshInterval time.Duration
+	// Leeway is the allowed clock skew for exp and nbf.
+	Leeway time.Duration
+}
+
+// NewJWKSVerifier creates a Verifier that verifies the signature of access tokens with the published public keys.
+//
+// It does not contact auth-service for each token, so a revoked access token is accepted until it expires.
+func NewJWKSVerifier(cfg JWKSVerifierConfig) Verifier {
+	if cfg.CacheDuration <= 0 {
+		cfg.CacheDuration = 5 * time.Minute
+	}
+	if cfg.MinRefreshInterval <= 0 {
+		cfg.MinRefreshInterval = time.Minute
+	}
+
+	return &jwksVerifier{
+		keySet: oidc.NewRemoteKeySet(oidc.RemoteKeySetConfig{
+			JWKSURL:            cfg.JWKSURL,
+			HTTPClient:         cfg.HTTPClient,
+			CacheDuration:      cfg.CacheDuration,
+			MinRefreshInterval: cfg.MinRefreshInterval,
+		}),
+		leeway: cfg.Leeway,
+	}
+}
+
+type jwksVerifier struct {
+	keySet oidc.KeySet
+	leeway time.Duration
+}
+
+func (v jwksVerifier) Verify(ctx context.Context, accessToken string) (Identity, error) {
+	var claims accessTokenClaims
+	_, err := jwt.ParseWithClaims(accessToken, &claims, func(token *jwt.Token) (any, error) {
+		kid, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, errors.New("missing kid header")
+		}
+
+		key, err := v.keySet.GetKey(ctx, kid)
+		if errors.Is(err, oidc.ErrKeyNotFound) {
+			return nil, err
+		} else if err != nil {
+			// the JWKS endpoint is unavailable, which is not the fault of the token
+			return nil, &keySetError{err: err}
+		}
+		return key, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(v.leeway),
+	)
+	if keySetErr := (*keySetError)(nil); errors.As(err, &keySetErr) {
+		return Identity{}, serrors.WithStackTrace(keySetErr.err)
+	} else if err != nil {
+		return Identity{}, serrors.WithStackTrace(fmt.Errorf("%w: %w", ErrInvalidToken, err))
+	}
+
+	tokenID, err := uuid.FromString(claims.ID)
+	if err != nil {
+		return Identity{}, serrors.WithStackTrace(fmt.Errorf("%w: invalid jti: %w", ErrInvalidToken, err))
+	}
+
+	userUUID, err := uuid.FromString(claims.Subject)
+	if err != nil {
+		return Identity{}, serrors.WithStackTrace(fmt.Errorf("%w: invalid sub: %w", ErrInvalidToken, err))
+	} else if userUUID.IsNil() {
+		return Identity{}, serrors.WithStackTrace(fmt.Errorf("%w: missing sub", ErrInvalidToken))
+	}
+
+	return Identity{
+		UserUUID:    userUUID,
+		TokenID:     tokenID,
+		ExpiresAt:   claims.ExpiresAt.Time,
+		Roles:       claims.Roles,
+		Permissions: strings.Fields(claims.Scope),
+	}, nil
+}
+
+type accessTokenClaims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles"`
+	Scope string   `json:"scope"`
+}
+
+type keySetError struct {
+	err error
+}
+
+func (e *keySetError) Error() string {
+	return e.err.Error()
+}
+
+func (e *keySetError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,89 @@
+package authclient_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/okocraft/auth-service/authclient"
+	"github.com/okocraft/auth-service/authclient/authclienttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWKSVerifier_Verify(t *testing.T) {
+	issuer := authclienttest.NewIssuer(t)
+	otherIssuer := authclienttest.NewIssuer(t)
+	userUUID := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			token: issuer.MintAccessToken(t, userUUID, time.Minute),
+		},
+		{
+			name:    "expired",
+			token:   issuer.MintAccessToken(t, userUUID, -time.Minute),
+			wantErr: true,
+		},
+		{
+			name:    "signed by another key",
+			token:   otherIssuer.MintAccessToken(t, userUUID, time.Minute),
+			wantErr: true,
+		},
+		{
+			name:    "without sub",
+			token:   issuer.MintAccessToken(t, uuid.Nil, time.Minute),
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			token:   "not-a-jwt",
+			wantErr: true,
+		},
+	}
+
+	verifier := issuer.Verifier()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := verifier.Verify(context.Background(), tt.token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, authclient.ErrInvalidToken)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, userUUID, identity.UserUUID)
+			assert.False(t, identity.TokenID.IsNil())
+		})
+	}
+}
+
+func TestJWKSVerifier_Verify_Unavailable(t *testing.T) {
+	issuer := authclienttest.NewIssuer(t)
+	token := issuer.MintAccessToken(t, uuid.Must(uuid.NewV4()), time.Minute)
+
+	verifier := authclient.NewJWKSVerifier(authclient.JWKSVerifierConfig{JWKSURL: "http://127.0.0.1:0/.well-known/jwks.json"})
+
+	_, err := verifier.Verify(context.Background(), token)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, authclient.ErrInvalidToken)
+}
+
+func TestJWKSVerifier_Verify_Roles(t *testing.T) {
+	issuer := authclienttest.NewIssuer(t)
+	token := issuer.MintAccessTokenWithRoles(t, uuid.Must(uuid.NewV4()), time.Minute, []string{"staff"}, []string{"panel:read", "panel:write"})
+
+	identity, err := issuer.Verifier().Verify(context.Background(), token)
+	require.NoError(t, err)
+
+	assert.True(t, identity.HasRole("staff"))
+	assert.False(t, identity.HasRole("admin"))
+	assert.True(t, identity.HasPermission("panel:write"))
+	assert.False(t, identity.HasPermission("panel"))
+}
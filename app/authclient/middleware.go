@@ -0,0 +1,36 @@
+package authclient
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// NewMiddleware creates a middleware that requires a valid bearer access token.
+//
+// The authenticated identity can be obtained with IdentityFromContext in the next handler.
+// It responds with 401 if the token is missing or invalid, and with 500 if the verifier fails.
+func NewMiddleware(verifier Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+			if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+				w.Header().Set("WWW-Authenticate", `Bearer`)
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			identity, err := verifier.Verify(r.Context(), token)
+			if errors.Is(err, ErrInvalidToken) {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			} else if err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), identity)))
+		})
+	}
+}
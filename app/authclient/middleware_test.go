@@ -0,0 +1,68 @@
+package authclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/okocraft/auth-service/authclient"
+	"github.com/okocraft/auth-service/authclient/authclienttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMiddleware(t *testing.T) {
+	issuer := authclienttest.NewIssuer(t)
+	userUUID := uuid.Must(uuid.NewV4())
+
+	handler := authclient.NewMiddleware(issuer.Verifier())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := authclient.IdentityFromContext(r.Context())
+		assert.True(t, ok)
+		assert.Equal(t, userUUID, identity.UserUUID)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+	}{
+		{
+			name:          "valid token",
+			authorization: "Bearer " + issuer.MintAccessToken(t, userUUID, time.Minute),
+			wantStatus:    http.StatusNoContent,
+		},
+		{
+			name:       "missing header",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "other scheme",
+			authorization: "Basic dXNlcjpwYXNz",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "expired token",
+			authorization: "Bearer " + issuer.MintAccessToken(t, userUUID, -time.Minute),
+			wantStatus:    http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Contains(t, rec.Header().Get("WWW-Authenticate"), "Bearer")
+			}
+		})
+	}
+}
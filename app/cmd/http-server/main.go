@@ -9,7 +9,9 @@ import (
 	"github.com/Siroshun09/logs"
 	"github.com/okocraft/auth-service/internal/config"
 	"github.com/okocraft/auth-service/internal/handler/http/server"
+	"github.com/okocraft/auth-service/internal/handler/worker"
 	"github.com/okocraft/auth-service/internal/repositories/database"
+	"github.com/okocraft/auth-service/internal/usecases"
 )
 
 func main() {
@@ -43,6 +45,12 @@ func main() {
 	logger.Info(ctx, "http server started")
 	defer stop()
 
+	if cfg.CleanupConfig.Enabled {
+		cleanupUsecase := usecases.NewUsecaseFactory(cfg.AuthConfig, db).NewCleanupUsecase(cfg.CleanupConfig)
+		go worker.NewCleanupWorker(cfg.CleanupConfig, cleanupUsecase).Run(srvCtx)
+		logger.Info(ctx, "cleanup worker started")
+	}
+
 	<-srvCtx.Done()
 	if err := httpServer.Shutdown(1 * time.Minute); err != nil {
 		logger.Error(ctx, err)
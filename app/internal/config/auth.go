@@ -1,11 +1,13 @@
 package config
 
 import (
-	"encoding/hex"
+	"crypto/ed25519"
 	"time"
 
 	"github.com/Siroshun09/serrors"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/okocraft/auth-service/internal/encryption"
+	"github.com/okocraft/auth-service/internal/signing"
 	"github.com/okocraft/authlib/encrypt"
 	"github.com/okocraft/authlib/jwtclaims"
 )
@@ -13,36 +15,64 @@ import (
 type AuthConfig struct {
 	Encrypter                  encrypt.Encrypter
 	JWTSigner                  jwtclaims.JWTSigner
+	AccessTokenSigner          signing.KeySigner
 	LoginExpireDuration        time.Duration
+	LoginKeyExpireDuration     time.Duration
 	AccessTokenExpireDuration  time.Duration
 	RefreshTokenExpireDuration time.Duration
 }
 
 func NewAuthConfigFromEnv() (AuthConfig, error) {
-	privateKeyHex, err := getRequiredString("AUTH_SERVICE_PRIVATE_KEY")
+	privateKey, err := getRequiredHexKey("AUTH_SERVICE_PRIVATE_KEY", 32)
 	if err != nil {
 		return AuthConfig{}, err
 	}
 
-	privateKey, err := hex.DecodeString(privateKeyHex)
+	// keys that were previously used as AUTH_SERVICE_PRIVATE_KEY, kept until the tokens and code verifiers they protect expire
+	retiredPrivateKeys, err := getHexKeys("AUTH_SERVICE_RETIRED_PRIVATE_KEYS", 32)
 	if err != nil {
-		return AuthConfig{}, serrors.Errorf("failed to decode AUTH_SERVICE_PRIVATE_KEY: %w", err)
-	} else if len(privateKey) != 32 {
-		return AuthConfig{}, serrors.New("AUTH_SERVICE_PRIVATE_KEY must be hex value of 32 bytes long")
+		return AuthConfig{}, err
+	}
+
+	signingKeySeed, err := getRequiredHexKey("AUTH_SERVICE_ACCESS_TOKEN_SIGNING_KEY", ed25519.SeedSize)
+	if err != nil {
+		return AuthConfig{}, err
+	}
+
+	retiredSigningKeySeeds, err := getHexKeys("AUTH_SERVICE_RETIRED_ACCESS_TOKEN_SIGNING_KEYS", ed25519.SeedSize)
+	if err != nil {
+		return AuthConfig{}, err
 	}
 
-	encrypter, err := encrypt.NewAESEncrypter(privateKey)
+	if err := validateMasterKeys(privateKey, retiredPrivateKeys, signingKeySeed); err != nil {
+		return AuthConfig{}, err
+	}
+
+	acceptLegacyKeys, err := getBoolFromEnv("AUTH_SERVICE_ACCEPT_LEGACY_PRIVATE_KEYS", true)
 	if err != nil {
-		return AuthConfig{}, serrors.Errorf("failed to create encrypter: %w", err)
+		return AuthConfig{}, err
 	}
 
-	jwtSigner := jwtclaims.NewJWTSigner(jwt.SigningMethodHS512, privateKey)
+	encrypter, jwtSigner, err := newKeyringsFromMasterKeys(privateKey, retiredPrivateKeys, acceptLegacyKeys)
+	if err != nil {
+		return AuthConfig{}, err
+	}
+
+	retiredSigningKeys := make([]ed25519.PrivateKey, 0, len(retiredSigningKeySeeds))
+	for _, seed := range retiredSigningKeySeeds {
+		retiredSigningKeys = append(retiredSigningKeys, ed25519.NewKeyFromSeed(seed))
+	}
 
 	loginExpire, err := getDurationFromEnv("AUTH_SERVICE_LOGIN_EXPIRE", 15*time.Minute)
 	if err != nil {
 		return AuthConfig{}, err
 	}
 
+	loginKeyExpire, err := getDurationFromEnv("AUTH_SERVICE_LOGIN_KEY_EXPIRE", 10*time.Minute)
+	if err != nil {
+		return AuthConfig{}, err
+	}
+
 	accessTokenExpire, err := getDurationFromEnv("AUTH_SERVICE_ACCESS_TOKEN_EXPIRE", 15*time.Minute)
 	if err != nil {
 		return AuthConfig{}, err
@@ -56,8 +86,49 @@ func NewAuthConfigFromEnv() (AuthConfig, error) {
 	return AuthConfig{
 		Encrypter:                  encrypter,
 		JWTSigner:                  jwtSigner,
+		AccessTokenSigner:          signing.NewEd25519Signer(ed25519.NewKeyFromSeed(signingKeySeed), retiredSigningKeys...),
 		LoginExpireDuration:        loginExpire,
+		LoginKeyExpireDuration:     loginKeyExpire,
 		AccessTokenExpireDuration:  accessTokenExpire,
 		RefreshTokenExpireDuration: refreshTokenExpire,
 	}, nil
 }
+
+// newKeyringsFromMasterKeys creates the encrypter and the JWT signer with the keys derived from the master keys.
+//
+// Before the keys were derived, the master keys were used as is for both purposes.
+// If acceptLegacyKeys is true, the master keys themselves are still accepted for decryption and verification (never for encryption and signing),
+// so that the state JWTs, refresh tokens and code verifiers issued before the migration remain valid.
+// It should be disabled once AUTH_SERVICE_REFRESH_TOKEN_EXPIRE has passed since the migration.
+func newKeyringsFromMasterKeys(privateKey []byte, retiredPrivateKeys [][]byte, acceptLegacyKeys bool) (encrypt.Encrypter, jwtclaims.JWTSigner, error) {
+	current, err := deriveKeys(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var retiredEncryptionKeys, retiredJWTSigningKeys [][]byte
+	for _, key := range retiredPrivateKeys {
+		retired, err := deriveKeys(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		retiredEncryptionKeys = append(retiredEncryptionKeys, retired.encryption)
+		retiredJWTSigningKeys = append(retiredJWTSigningKeys, retired.jwtSigning)
+	}
+
+	if acceptLegacyKeys {
+		for _, key := range append([][]byte{privateKey}, retiredPrivateKeys...) {
+			retiredEncryptionKeys = append(retiredEncryptionKeys, key)
+			retiredJWTSigningKeys = append(retiredJWTSigningKeys, key)
+		}
+	}
+
+	encrypter, err := encryption.NewAESKeyringEncrypter(current.encryption, retiredEncryptionKeys...)
+	if err != nil {
+		return nil, nil, serrors.Errorf("failed to create encrypter: %w", err)
+	}
+
+	jwtSigner := signing.NewHMACSigner(jwt.SigningMethodHS512, current.jwtSigning, retiredJWTSigningKeys...)
+
+	return encrypter, jwtSigner, nil
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"math"
+	"time"
+
+	"github.com/Siroshun09/serrors"
+)
+
+type CleanupConfig struct {
+	Enabled   bool
+	Interval  time.Duration
+	BatchSize int32
+}
+
+func NewCleanupConfigFromEnv() (CleanupConfig, error) {
+	enabled, err := getBoolFromEnv("AUTH_SERVICE_CLEANUP_ENABLED", true)
+	if err != nil {
+		return CleanupConfig{}, err
+	}
+
+	interval, err := getDurationFromEnv("AUTH_SERVICE_CLEANUP_INTERVAL", 1*time.Hour)
+	if err != nil {
+		return CleanupConfig{}, err
+	} else if interval <= 0 {
+		return CleanupConfig{}, serrors.New("AUTH_SERVICE_CLEANUP_INTERVAL must be positive")
+	}
+
+	batchSize, err := getIntFromEnv("AUTH_SERVICE_CLEANUP_BATCH_SIZE", 1000)
+	if err != nil {
+		return CleanupConfig{}, err
+	} else if batchSize <= 0 || math.MaxInt32 < batchSize {
+		return CleanupConfig{}, serrors.New("AUTH_SERVICE_CLEANUP_BATCH_SIZE must be a positive 32-bit integer")
+	}
+
+	return CleanupConfig{
+		Enabled:   enabled,
+		Interval:  interval,
+		BatchSize: int32(batchSize),
+	}, nil
+}
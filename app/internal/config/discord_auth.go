@@ -0,0 +1,38 @@
+package config
+
+import "os"
+
+type DiscordAuthConfig struct {
+	Enabled      bool
+	RedirectURL  string
+	ClientID     string
+	ClientSecret string
+}
+
+func NewDiscordAuthConfigFromEnv() (DiscordAuthConfig, error) {
+	if os.Getenv("AUTH_SERVICE_DISCORD_AUTH_ENABLED") != "true" {
+		return DiscordAuthConfig{}, nil
+	}
+
+	redirectURL, err := getRequiredString("AUTH_SERVICE_DISCORD_AUTH_REDIRECT_URL")
+	if err != nil {
+		return DiscordAuthConfig{}, err
+	}
+
+	clientID, err := getRequiredString("AUTH_SERVICE_DISCORD_AUTH_CLIENT_ID")
+	if err != nil {
+		return DiscordAuthConfig{}, err
+	}
+
+	clientSecret, err := getRequiredString("AUTH_SERVICE_DISCORD_AUTH_CLIENT_SECRET")
+	if err != nil {
+		return DiscordAuthConfig{}, err
+	}
+
+	return DiscordAuthConfig{
+		Enabled:      true,
+		RedirectURL:  redirectURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}, nil
+}
@@ -2,6 +2,7 @@ package config
 
 import "os"
 
+// GoogleAuthConfig is kept for the AUTH_SERVICE_GOOGLE_AUTH_* variables, which configure the "google" OIDC provider.
 type GoogleAuthConfig struct {
 	Enabled       bool
 	RedirectURL   string
@@ -30,10 +31,7 @@ func NewGoogleAuthConfigFromEnv() (GoogleAuthConfig, error) {
 		return GoogleAuthConfig{}, err
 	}
 
-	resultPageURL, err := getRequiredString("AUTH_SERVICE_GOOGLE_AUTH_RESULT_PAGE_URL")
-	if err != nil {
-		return GoogleAuthConfig{}, err
-	}
+	resultPageURL := os.Getenv("AUTH_SERVICE_GOOGLE_AUTH_RESULT_PAGE_URL")
 
 	return GoogleAuthConfig{
 		Enabled:       true,
@@ -43,3 +41,15 @@ func NewGoogleAuthConfigFromEnv() (GoogleAuthConfig, error) {
 		ResultPageURL: resultPageURL,
 	}, nil
 }
+
+func (c GoogleAuthConfig) ToOIDCProviderConfig() OIDCProviderConfig {
+	return OIDCProviderConfig{
+		Name:              GoogleProviderName,
+		Issuer:            "https://accounts.google.com",
+		ClientID:          c.ClientID,
+		ClientSecret:      c.ClientSecret,
+		RedirectURL:       c.RedirectURL,
+		Scopes:            []string{"openid"},
+		AdditionalIssuers: []string{"accounts.google.com"},
+	}
+}
@@ -1,17 +1,24 @@
 package config
 
 import (
+	"net/url"
 	"os"
 	"strings"
 )
 
 type HTTPServerConfig struct {
-	Debug            bool
-	Port             string
-	AllowedOrigins   map[string]struct{}
-	DBConfig         DBConfig
-	AuthConfig       AuthConfig
-	GoogleAuthConfig GoogleAuthConfig
+	Debug          bool
+	Port           string
+	AllowedOrigins map[string]struct{}
+	// AllowedRedirectURLs are the origins or URL prefixes that the client can be redirected to after login.
+	AllowedRedirectURLs []*url.URL
+	DBConfig            DBConfig
+	AuthConfig          AuthConfig
+	OAuthConfig         OAuthConfig
+	ServiceAuthConfig   ServiceAuthConfig
+	AdminAuthConfig     AdminAuthConfig
+	RateLimitConfig     RateLimitConfig
+	CleanupConfig       CleanupConfig
 }
 
 func NewHTTPServerConfigFromEnv() (HTTPServerConfig, error) {
@@ -27,6 +34,11 @@ func NewHTTPServerConfigFromEnv() (HTTPServerConfig, error) {
 
 	origins := createOriginSet(os.Getenv("AUTH_SERVICE_ALLOWED_ORIGINS"))
 
+	allowedRedirectURLs, err := getAllowedRedirectURLsFromEnv(origins)
+	if err != nil {
+		return HTTPServerConfig{}, err
+	}
+
 	dbConfig, err := NewDBConfigFromEnv()
 	if err != nil {
 		return HTTPServerConfig{}, err
@@ -37,18 +49,43 @@ func NewHTTPServerConfigFromEnv() (HTTPServerConfig, error) {
 		return HTTPServerConfig{}, err
 	}
 
-	googleAuthConfig, err := NewGoogleAuthConfigFromEnv()
+	oauthConfig, err := NewOAuthConfigFromEnv()
+	if err != nil {
+		return HTTPServerConfig{}, err
+	}
+
+	serviceAuthConfig, err := NewServiceAuthConfigFromEnv()
+	if err != nil {
+		return HTTPServerConfig{}, err
+	}
+
+	adminAuthConfig, err := NewAdminAuthConfigFromEnv()
+	if err != nil {
+		return HTTPServerConfig{}, err
+	}
+
+	rateLimitConfig, err := NewRateLimitConfigFromEnv()
+	if err != nil {
+		return HTTPServerConfig{}, err
+	}
+
+	cleanupConfig, err := NewCleanupConfigFromEnv()
 	if err != nil {
 		return HTTPServerConfig{}, err
 	}
 
 	return HTTPServerConfig{
-		Debug:            debug,
-		Port:             port,
-		AllowedOrigins:   origins,
-		DBConfig:         dbConfig,
-		AuthConfig:       authConfig,
-		GoogleAuthConfig: googleAuthConfig,
+		Debug:               debug,
+		Port:                port,
+		AllowedOrigins:      origins,
+		AllowedRedirectURLs: allowedRedirectURLs,
+		DBConfig:            dbConfig,
+		AuthConfig:          authConfig,
+		OAuthConfig:         oauthConfig,
+		ServiceAuthConfig:   serviceAuthConfig,
+		AdminAuthConfig:     adminAuthConfig,
+		RateLimitConfig:     rateLimitConfig,
+		CleanupConfig:       cleanupConfig,
 	}, nil
 }
 
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"bytes"
+	"crypto/hkdf"
+	"crypto/sha256"
+
+	"github.com/Siroshun09/serrors"
+)
+
+const (
+	// hkdfInfoEncryption and hkdfInfoJWTSigning bind the derived keys to their purposes, so that a key derived for one purpose is useless for the other.
+	hkdfInfoEncryption = "okocraft-auth-service/aes-256-gcm/v1"
+	hkdfInfoJWTSigning = "okocraft-auth-service/jwt-hs512/v1"
+
+	encryptionKeySize = 32
+	jwtSigningKeySize = 64 // the block size of SHA-512, as recommended for HS512
+)
+
+// derivedKeys holds the independent keys derived from a master secret.
+type derivedKeys struct {
+	encryption []byte
+	jwtSigning []byte
+}
+
+func deriveKeys(masterKey []byte) (derivedKeys, error) {
+	encryptionKey, err := hkdf.Key(sha256.New, masterKey, nil, hkdfInfoEncryption, encryptionKeySize)
+	if err != nil {
+		return derivedKeys{}, serrors.WithStackTrace(err)
+	}
+
+	jwtSigningKey, err := hkdf.Key(sha256.New, masterKey, nil, hkdfInfoJWTSigning, jwtSigningKeySize)
+	if err != nil {
+		return derivedKeys{}, serrors.WithStackTrace(err)
+	}
+
+	return derivedKeys{
+		encryption: encryptionKey,
+		jwtSigning: jwtSigningKey,
+	}, nil
+}
+
+// validateMasterKeys rejects configurations in which the same key material would be used for different purposes.
+func validateMasterKeys(privateKey []byte, retiredPrivateKeys [][]byte, accessTokenSigningKeySeed []byte) error {
+	if bytes.Equal(privateKey, make([]byte, len(privateKey))) {
+		return serrors.New("AUTH_SERVICE_PRIVATE_KEY must not be all zeros")
+	}
+
+	if bytes.Equal(privateKey, accessTokenSigningKeySeed) {
+		return serrors.New("AUTH_SERVICE_ACCESS_TOKEN_SIGNING_KEY must be different from AUTH_SERVICE_PRIVATE_KEY")
+	}
+
+	for _, retired := range retiredPrivateKeys {
+		if bytes.Equal(privateKey, retired) {
+			return serrors.New("AUTH_SERVICE_RETIRED_PRIVATE_KEYS must not contain AUTH_SERVICE_PRIVATE_KEY")
+		}
+	}
+
+	return nil
+}
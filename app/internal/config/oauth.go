@@ -0,0 +1,134 @@
+package config
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/Siroshun09/serrors"
+)
+
+const (
+	GoogleProviderName  = "google"
+	DiscordProviderName = "discord"
+)
+
+var providerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+type OAuthConfig struct {
+	ResultPageURL string
+	Providers     []OIDCProviderConfig
+	Discord       DiscordAuthConfig
+}
+
+type OIDCProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// AdditionalIssuers are accepted as iss of ID tokens in addition to Issuer.
+	AdditionalIssuers []string
+}
+
+func NewOAuthConfigFromEnv() (OAuthConfig, error) {
+	googleAuthConfig, err := NewGoogleAuthConfigFromEnv()
+	if err != nil {
+		return OAuthConfig{}, err
+	}
+
+	var providers []OIDCProviderConfig
+	if googleAuthConfig.Enabled {
+		providers = append(providers, googleAuthConfig.ToOIDCProviderConfig())
+	}
+
+	for name := range strings.SplitSeq(os.Getenv("AUTH_SERVICE_OIDC_PROVIDERS"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		provider, err := newOIDCProviderConfigFromEnv(name)
+		if err != nil {
+			return OAuthConfig{}, err
+		}
+		providers = append(providers, provider)
+	}
+
+	discordAuthConfig, err := NewDiscordAuthConfigFromEnv()
+	if err != nil {
+		return OAuthConfig{}, err
+	}
+
+	seen := make(map[string]struct{}, len(providers)+1)
+	if discordAuthConfig.Enabled {
+		seen[DiscordProviderName] = struct{}{}
+	}
+	for _, provider := range providers {
+		if _, ok := seen[provider.Name]; ok {
+			return OAuthConfig{}, serrors.New("oauth provider '" + provider.Name + "' is configured more than once")
+		}
+		seen[provider.Name] = struct{}{}
+	}
+
+	if len(seen) == 0 {
+		return OAuthConfig{}, nil
+	}
+
+	resultPageURL := os.Getenv("AUTH_SERVICE_OAUTH_RESULT_PAGE_URL")
+	if resultPageURL == "" {
+		resultPageURL = googleAuthConfig.ResultPageURL
+	}
+	if resultPageURL == "" {
+		return OAuthConfig{}, serrors.New("env 'AUTH_SERVICE_OAUTH_RESULT_PAGE_URL' is required")
+	}
+
+	return OAuthConfig{
+		ResultPageURL: resultPageURL,
+		Providers:     providers,
+		Discord:       discordAuthConfig,
+	}, nil
+}
+
+func newOIDCProviderConfigFromEnv(name string) (OIDCProviderConfig, error) {
+	if !providerNamePattern.MatchString(name) {
+		return OIDCProviderConfig{}, serrors.New("invalid oidc provider name: " + name)
+	}
+
+	prefix := "AUTH_SERVICE_OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+	issuer, err := getRequiredString(prefix + "ISSUER")
+	if err != nil {
+		return OIDCProviderConfig{}, err
+	}
+
+	clientID, err := getRequiredString(prefix + "CLIENT_ID")
+	if err != nil {
+		return OIDCProviderConfig{}, err
+	}
+
+	clientSecret, err := getRequiredString(prefix + "CLIENT_SECRET")
+	if err != nil {
+		return OIDCProviderConfig{}, err
+	}
+
+	redirectURL, err := getRequiredString(prefix + "REDIRECT_URL")
+	if err != nil {
+		return OIDCProviderConfig{}, err
+	}
+
+	scopes := []string{"openid"}
+	if value := os.Getenv(prefix + "SCOPES"); value != "" {
+		scopes = strings.Fields(strings.ReplaceAll(value, ",", " "))
+	}
+
+	return OIDCProviderConfig{
+		Name:         name,
+		Issuer:       issuer,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       scopes,
+	}, nil
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Siroshun09/serrors"
+)
+
+type RateLimitStore string
+
+const (
+	RateLimitStoreMemory   RateLimitStore = "memory"
+	RateLimitStoreDatabase RateLimitStore = "database"
+)
+
+// RateLimitRule allows Burst requests per Period. If Lockout is positive, the requests are rejected for Lockout after exceeding the limit.
+// The zero value disables the rule.
+type RateLimitRule struct {
+	Burst   int
+	Period  time.Duration
+	Lockout time.Duration
+}
+
+type RateLimitConfig struct {
+	Enabled bool
+	Store   RateLimitStore
+	// Login limits the OAuth login, link and callback requests per IP address.
+	Login RateLimitRule
+	// LoginKey limits the link requests per login key.
+	LoginKey RateLimitRule
+	// Refresh limits the token refresh requests per IP address.
+	Refresh RateLimitRule
+	// User limits the requests with an access token per user.
+	User RateLimitRule
+}
+
+func NewRateLimitConfigFromEnv() (RateLimitConfig, error) {
+	enabled, err := getBoolFromEnv("AUTH_SERVICE_RATE_LIMIT_ENABLED", true)
+	if err != nil {
+		return RateLimitConfig{}, err
+	}
+
+	store := RateLimitStore(os.Getenv("AUTH_SERVICE_RATE_LIMIT_STORE"))
+	switch store {
+	case "":
+		store = RateLimitStoreMemory
+	case RateLimitStoreMemory, RateLimitStoreDatabase:
+	default:
+		return RateLimitConfig{}, serrors.Errorf("unknown AUTH_SERVICE_RATE_LIMIT_STORE: %s", store)
+	}
+
+	login, err := getRateLimitRuleFromEnv("AUTH_SERVICE_RATE_LIMIT_LOGIN", RateLimitRule{Burst: 30, Period: time.Minute})
+	if err != nil {
+		return RateLimitConfig{}, err
+	}
+
+	loginKey, err := getRateLimitRuleFromEnv("AUTH_SERVICE_RATE_LIMIT_LOGIN_KEY", RateLimitRule{Burst: 5, Period: 10 * time.Minute, Lockout: 30 * time.Minute})
+	if err != nil {
+		return RateLimitConfig{}, err
+	}
+
+	refresh, err := getRateLimitRuleFromEnv("AUTH_SERVICE_RATE_LIMIT_REFRESH", RateLimitRule{Burst: 60, Period: time.Minute})
+	if err != nil {
+		return RateLimitConfig{}, err
+	}
+
+	user, err := getRateLimitRuleFromEnv("AUTH_SERVICE_RATE_LIMIT_USER", RateLimitRule{Burst: 120, Period: time.Minute})
+	if err != nil {
+		return RateLimitConfig{}, err
+	}
+
+	return RateLimitConfig{
+		Enabled:  enabled,
+		Store:    store,
+		Login:    login,
+		LoginKey: loginKey,
+		Refresh:  refresh,
+		User:     user,
+	}, nil
+}
+
+// getRateLimitRuleFromEnv reads the rule in the form of "<burst>/<period>[/<lockout>]" (e.g. "5/10m/30m"), or "off" to disable the rule.
+func getRateLimitRuleFromEnv(key string, defaultValue RateLimitRule) (RateLimitRule, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return defaultValue, nil
+	} else if value == "off" {
+		return RateLimitRule{}, nil
+	}
+
+	parts := strings.Split(value, "/")
+	if len(parts) < 2 || 3 < len(parts) {
+		return RateLimitRule{}, serrors.Errorf("%s must be in the form of <burst>/<period>[/<lockout>]", key)
+	}
+
+	burst, err := strconv.Atoi(parts[0])
+	if err != nil || burst <= 0 {
+		return RateLimitRule{}, serrors.Errorf("the burst of %s must be a positive integer", key)
+	}
+
+	period, err := time.ParseDuration(parts[1])
+	if err != nil || period <= 0 {
+		return RateLimitRule{}, serrors.Errorf("the period of %s must be a positive duration", key)
+	}
+
+	var lockout time.Duration
+	if len(parts) == 3 {
+		lockout, err = time.ParseDuration(parts[2])
+		if err != nil || lockout < 0 {
+			return RateLimitRule{}, serrors.Errorf("the lockout of %s must be a non-negative duration", key)
+		}
+	}
+
+	return RateLimitRule{Burst: burst, Period: period, Lockout: lockout}, nil
+}
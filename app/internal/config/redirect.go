@@ -0,0 +1,45 @@
+package config
+
+import (
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/Siroshun09/serrors"
+)
+
+// getAllowedRedirectURLsFromEnv reads the comma-separated origins or URL prefixes (e.g. https://example.com/app/) that the client can be redirected to after login.
+// The allowed origins are used if the env is not set.
+func getAllowedRedirectURLsFromEnv(origins map[string]struct{}) ([]*url.URL, error) {
+	var values []string
+	if value := os.Getenv("AUTH_SERVICE_ALLOWED_REDIRECT_URLS"); value != "" {
+		values = strings.Split(value, ",")
+	} else {
+		for origin := range origins {
+			values = append(values, origin)
+		}
+		slices.Sort(values)
+	}
+
+	allowed := make([]*url.URL, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		u, err := url.Parse(value)
+		if err != nil {
+			return nil, serrors.Errorf("invalid allowed redirect url '%s': %w", value, err)
+		}
+
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+			return nil, serrors.Errorf("allowed redirect url '%s' must be an http(s) origin or url prefix without credentials, query and fragment", value)
+		}
+
+		allowed = append(allowed, u)
+	}
+
+	return allowed, nil
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"os"
+	"strings"
+
+	"github.com/Siroshun09/serrors"
+)
+
+const apiKeyMinLength = 32
+
+type ServiceAuthConfig struct {
+	// APIKeys are the pre-shared keys for other okocraft services. The service API is disabled if empty.
+	APIKeys []string
+}
+
+func NewServiceAuthConfigFromEnv() (ServiceAuthConfig, error) {
+	keys, err := getAPIKeysFromEnv("AUTH_SERVICE_SERVICE_API_KEYS")
+	if err != nil {
+		return ServiceAuthConfig{}, err
+	}
+	return ServiceAuthConfig{APIKeys: keys}, nil
+}
+
+type AdminAuthConfig struct {
+	// APIKeys are the keys for operators to use the admin API. The admin API is disabled if empty.
+	APIKeys []string
+}
+
+func NewAdminAuthConfigFromEnv() (AdminAuthConfig, error) {
+	keys, err := getAPIKeysFromEnv("AUTH_SERVICE_ADMIN_API_KEYS")
+	if err != nil {
+		return AdminAuthConfig{}, err
+	}
+	return AdminAuthConfig{APIKeys: keys}, nil
+}
+
+// getAPIKeysFromEnv reads the comma-separated API keys.
+func getAPIKeysFromEnv(key string) ([]string, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	var keys []string
+	for apiKey := range strings.SplitSeq(value, ",") {
+		apiKey = strings.TrimSpace(apiKey)
+		if len(apiKey) < apiKeyMinLength {
+			return nil, serrors.Errorf("each of %s must be at least %d characters long", key, apiKeyMinLength)
+		}
+		keys = append(keys, apiKey)
+	}
+
+	return keys, nil
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"encoding/hex"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Siroshun09/serrors"
@@ -43,3 +45,59 @@ func getDurationFromEnv(key string, defaultValue time.Duration) (time.Duration,
 
 	return d, nil
 }
+
+func getIntFromEnv(key string, defaultValue int) (int, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return defaultValue, nil
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, serrors.WithStackTrace(err)
+	}
+
+	return i, nil
+}
+
+func getRequiredHexKey(key string, size int) ([]byte, error) {
+	value, err := getRequiredString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeHexKey(key, value, size)
+}
+
+// getHexKeys reads the comma-separated hex values of keys.
+func getHexKeys(key string, size int) ([][]byte, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	var keys [][]byte
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		decoded, err := decodeHexKey(key, v, size)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, decoded)
+	}
+	return keys, nil
+}
+
+func decodeHexKey(key string, value string, size int) ([]byte, error) {
+	decoded, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, serrors.Errorf("failed to decode %s: %w", key, err)
+	} else if len(decoded) != size {
+		return nil, serrors.Errorf("%s must be hex value of %d bytes long", key, size)
+	}
+	return decoded, nil
+}
@@ -15,11 +15,40 @@ const (
 	AccessLogActionTypeLogout
 	AccessLogActionTypeFirstLogin
 	AccessLogActionTypeRefreshToken
+	AccessLogActionTypeRefreshTokenReuseDetected
+	AccessLogActionTypeSessionRevoked
+	AccessLogActionTypeTokenRevoked
+	AccessLogActionTypeRevokedByAdmin
+	AccessLogActionTypeLoginDenied
+	AccessLogActionTypeRefreshDenied
+	AccessLogActionTypeLoginFailed
+	AccessLogActionTypeRefreshFailed
+)
+
+// AccessLogFailureReason is the reason why the attempt recorded in the access log failed.
+type AccessLogFailureReason int8
+
+const (
+	AccessLogFailureReasonNone AccessLogFailureReason = iota
+	AccessLogFailureReasonInvalidState
+	AccessLogFailureReasonProviderError
+	AccessLogFailureReasonUnknownSubject
+	AccessLogFailureReasonLoginKeyNotFound
+	AccessLogFailureReasonLoginKeyExpired
+	AccessLogFailureReasonSubjectAlreadyLinked
+	AccessLogFailureReasonCSRFMismatch
+	AccessLogFailureReasonInvalidRefreshToken
+	AccessLogFailureReasonAccountSuspended
 )
 
 type AccessLog struct {
-	UserID    user.ID
+	ID int64
+	// UserID is 0 if the attempt cannot be attributed to a user.
+	UserID user.ID
+	// UserUUID is only set when listing failed attempts across users.
+	UserUUID  uuid.UUID
 	Action    AccessLogActionType
+	Reason    AccessLogFailureReason
 	LoginID   uuid.UUID
 	IP        net.IP
 	UserAgent string
@@ -27,13 +56,28 @@ type AccessLog struct {
 }
 
 type AccessLogParams struct {
-	Action    AccessLogActionType
+	Action AccessLogActionType
+	Reason AccessLogFailureReason
+	// LoginID is uuid.Nil if the event is not tied to a login.
 	LoginID   uuid.UUID
 	IP        net.IP
 	UserAgent string
 	CreatedAt time.Time
 }
 
+// AccessLogPageParams selects a page of access logs ordered newest first.
+// Before is the ID of the oldest access log in the previous page, or 0 for the first page.
+type AccessLogPageParams struct {
+	Before int64
+	Limit  int32
+}
+
+type AccessLogPage struct {
+	AccessLogs []AccessLog
+	// NextBefore is the cursor of the next page, or 0 if this is the last page.
+	NextBefore int64
+}
+
 const UserAgentMaxLength = 255
 
 func TruncateUserAgent(userAgent string) string {
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/okocraft/authlib/user"
+)
+
+type AccessTokenOwner struct {
+	UserID   user.ID
+	UserUUID uuid.UUID
+	LoginID  uuid.UUID
+	// Authorization is the roles and the permissions granted when the access token was issued.
+	Authorization Authorization
+}
+
+// AccessTokenIntrospection is the state of the access token described in RFC 7662.
+type AccessTokenIntrospection struct {
+	Active        bool
+	UserUUID      uuid.UUID
+	JTI           uuid.UUID
+	IssuedAt      time.Time
+	ExpiresAt     time.Time
+	Authorization Authorization
+}
@@ -0,0 +1,9 @@
+package domain
+
+type CleanupResult struct {
+	DeletedAccessTokens     int64
+	DeletedRefreshTokens    int64
+	DeletedLoginKeys        int64
+	DeletedUsedLoginStates  int64
+	DeletedRateLimitBuckets int64
+}
@@ -27,7 +27,25 @@ func IsUnauthorizedError(err error) bool {
 
 var (
 	RefreshTokenIDByJTINotFoundError = errors.New("refresh token id by jti not found")
+	RefreshTokenAlreadyConsumedError = errors.New("refresh token already consumed")
+	LoginStateAlreadyUsedError       = errors.New("login state already used")
+	AccessTokenNotFoundError         = errors.New("access token not found")
+	LoginNotFoundByTokenError        = errors.New("login not found by token")
+	SessionNotFoundError             = errors.New("session not found")
 	SubAlreadyLinkedError            = errors.New("sub already linked")
+	UserNotFoundError                = errors.New("user not found")
+	AccountSuspendedError            = errors.New("account suspended")
+	InvalidUserStatusError           = errors.New("invalid user status")
 	UserNotFoundBySubError           = errors.New("user not found by sub")
 	UserNotFoundByLoginKeyError      = errors.New("user not found by login key")
+	LoginKeyExpiredError             = errors.New("login key expired")
+	RedirectURLNotAllowedError       = errors.New("redirect url not allowed")
+	IdentityNotFoundError            = errors.New("identity not found")
+	LastIdentityUnlinkError          = errors.New("cannot unlink the last identity")
+	RoleNotFoundError                = errors.New("role not found")
+	RoleAlreadyAssignedError         = errors.New("role already assigned")
+	RoleNotAssignedError             = errors.New("role not assigned")
+	PermissionNotFoundError          = errors.New("permission not found")
+	InvalidRoleNameError             = errors.New("invalid role name")
+	InvalidPermissionError           = errors.New("invalid permission")
 )
@@ -0,0 +1,10 @@
+package domain
+
+import "time"
+
+// Identity is an external account linked to the user, identified by the provider and the provider-scoped subject.
+type Identity struct {
+	Provider  string
+	Subject   string
+	CreatedAt time.Time
+}
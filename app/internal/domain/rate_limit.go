@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimit is a token bucket that holds up to Burst tokens and refills Burst tokens per Period.
+//
+// If Lockout is positive, the key that runs out of tokens is rejected until the lockout ends.
+type RateLimit struct {
+	Burst   int
+	Period  time.Duration
+	Lockout time.Duration
+}
+
+func (l RateLimit) Enabled() bool {
+	return 0 < l.Burst && 0 < l.Period
+}
+
+// tokenInterval returns the duration to refill a single token.
+func (l RateLimit) tokenInterval() time.Duration {
+	return l.Period / time.Duration(l.Burst)
+}
+
+type RateLimitBucket struct {
+	Tokens      float64
+	UpdatedAt   time.Time
+	LockedUntil time.Time
+}
+
+// NewRateLimitBucket returns the full bucket of the limit.
+func NewRateLimitBucket(limit RateLimit, now time.Time) RateLimitBucket {
+	return RateLimitBucket{Tokens: float64(limit.Burst), UpdatedAt: now}
+}
+
+type RateLimitResult struct {
+	Allowed bool
+	// RetryAfter is the duration until the next request is allowed, set only if the request is rejected.
+	RetryAfter time.Duration
+}
+
+// Take refills the bucket by the elapsed time and takes a token from it.
+func (b RateLimitBucket) Take(limit RateLimit, now time.Time) (RateLimitBucket, RateLimitResult) {
+	if now.Before(b.LockedUntil) {
+		return b, RateLimitResult{RetryAfter: b.LockedUntil.Sub(now)}
+	}
+
+	interval := limit.tokenInterval()
+	elapsed := max(now.Sub(b.UpdatedAt), 0)
+	tokens := math.Min(float64(limit.Burst), b.Tokens+float64(elapsed)/float64(interval))
+
+	if 1 <= tokens {
+		return RateLimitBucket{Tokens: tokens - 1, UpdatedAt: now}, RateLimitResult{Allowed: true}
+	}
+
+	if 0 < limit.Lockout {
+		return RateLimitBucket{Tokens: tokens, UpdatedAt: now, LockedUntil: now.Add(limit.Lockout)}, RateLimitResult{RetryAfter: limit.Lockout}
+	}
+
+	retryAfter := time.Duration(math.Ceil((1 - tokens) * float64(interval)))
+	return RateLimitBucket{Tokens: tokens, UpdatedAt: now}, RateLimitResult{RetryAfter: retryAfter}
+}
+
+// ExpiresAt returns the time when the bucket becomes full and unlocked again.
+// After that, the bucket can be forgotten because a new bucket behaves the same.
+func (b RateLimitBucket) ExpiresAt(limit RateLimit) time.Time {
+	missing := max(float64(limit.Burst)-b.Tokens, 0)
+	fullAt := b.UpdatedAt.Add(time.Duration(math.Ceil(missing * float64(limit.tokenInterval()))))
+	if fullAt.Before(b.LockedUntil) {
+		return b.LockedUntil
+	}
+	return fullAt
+}
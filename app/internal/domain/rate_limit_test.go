@@ -0,0 +1,106 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/okocraft/auth-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitBucket_Take(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limit := domain.RateLimit{Burst: 2, Period: 10 * time.Second}
+
+	tests := []struct {
+		name   string
+		limit  domain.RateLimit
+		bucket domain.RateLimitBucket
+		want   domain.RateLimitResult
+		tokens float64
+	}{
+		{
+			name:   "full bucket",
+			limit:  limit,
+			bucket: domain.NewRateLimitBucket(limit, now),
+			want:   domain.RateLimitResult{Allowed: true},
+			tokens: 1,
+		},
+		{
+			name:   "empty bucket",
+			limit:  limit,
+			bucket: domain.RateLimitBucket{Tokens: 0, UpdatedAt: now},
+			want:   domain.RateLimitResult{RetryAfter: 5 * time.Second},
+			tokens: 0,
+		},
+		{
+			name:   "refilled by the elapsed time",
+			limit:  limit,
+			bucket: domain.RateLimitBucket{Tokens: 0, UpdatedAt: now.Add(-5 * time.Second)},
+			want:   domain.RateLimitResult{Allowed: true},
+			tokens: 0,
+		},
+		{
+			name:   "refilled up to the burst",
+			limit:  limit,
+			bucket: domain.RateLimitBucket{Tokens: 0, UpdatedAt: now.Add(-time.Hour)},
+			want:   domain.RateLimitResult{Allowed: true},
+			tokens: 1,
+		},
+		{
+			name:   "locked out on empty bucket",
+			limit:  domain.RateLimit{Burst: 2, Period: 10 * time.Second, Lockout: time.Minute},
+			bucket: domain.RateLimitBucket{Tokens: 0, UpdatedAt: now},
+			want:   domain.RateLimitResult{RetryAfter: time.Minute},
+			tokens: 0,
+		},
+		{
+			name:   "still locked out",
+			limit:  domain.RateLimit{Burst: 2, Period: 10 * time.Second, Lockout: time.Minute},
+			bucket: domain.RateLimitBucket{Tokens: 2, UpdatedAt: now, LockedUntil: now.Add(30 * time.Second)},
+			want:   domain.RateLimitResult{RetryAfter: 30 * time.Second},
+			tokens: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket, got := tt.bucket.Take(tt.limit, now)
+			assert.Equal(t, tt.want, got)
+			assert.InDelta(t, tt.tokens, bucket.Tokens, 1e-9)
+		})
+	}
+}
+
+func TestRateLimitBucket_ExpiresAt(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limit := domain.RateLimit{Burst: 2, Period: 10 * time.Second}
+
+	tests := []struct {
+		name   string
+		bucket domain.RateLimitBucket
+		want   time.Time
+	}{
+		{
+			name:   "full bucket",
+			bucket: domain.NewRateLimitBucket(limit, now),
+			want:   now,
+		},
+		{
+			name:   "empty bucket",
+			bucket: domain.RateLimitBucket{Tokens: 0, UpdatedAt: now},
+			want:   now.Add(10 * time.Second),
+		},
+		{
+			name:   "locked bucket",
+			bucket: domain.RateLimitBucket{Tokens: 0, UpdatedAt: now, LockedUntil: now.Add(time.Minute)},
+			want:   now.Add(time.Minute),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.bucket.ExpiresAt(limit))
+		})
+	}
+}
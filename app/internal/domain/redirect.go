@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"net"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/Siroshun09/serrors"
+)
+
+// RedirectAllowlist checks the URLs that the client can be redirected to after login.
+type RedirectAllowlist struct {
+	entries []redirectAllowlistEntry
+}
+
+type redirectAllowlistEntry struct {
+	origin     string
+	pathPrefix string
+}
+
+// NewRedirectAllowlist creates the RedirectAllowlist from the origins or URL prefixes.
+// An entry without a path allows every path of the origin, and an entry with a path allows the path and the paths under it.
+func NewRedirectAllowlist(allowed []*url.URL) RedirectAllowlist {
+	entries := make([]redirectAllowlistEntry, 0, len(allowed))
+	for _, u := range allowed {
+		normalized, ok := normalizeRedirectURL(u)
+		if !ok {
+			continue
+		}
+
+		entries = append(entries, redirectAllowlistEntry{
+			origin:     normalized.Scheme + "://" + normalized.Host,
+			pathPrefix: normalized.Path,
+		})
+	}
+	return RedirectAllowlist{entries: entries}
+}
+
+// NormalizeRedirectURL returns the normalized rawURL, or RedirectURLNotAllowedError if it is not under any of the allowed URLs.
+// An empty rawURL is returned as is because it does not redirect the client anywhere.
+func (l RedirectAllowlist) NormalizeRedirectURL(rawURL string) (string, error) {
+	if rawURL == "" {
+		return "", nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", serrors.WithStackTrace(RedirectURLNotAllowedError)
+	}
+
+	normalized, ok := normalizeRedirectURL(u)
+	if !ok {
+		return "", serrors.WithStackTrace(RedirectURLNotAllowedError)
+	}
+
+	origin := normalized.Scheme + "://" + normalized.Host
+	for _, entry := range l.entries {
+		if entry.origin == origin && hasPathPrefix(normalized.Path, entry.pathPrefix) {
+			return normalized.String(), nil
+		}
+	}
+
+	return "", serrors.WithStackTrace(RedirectURLNotAllowedError)
+}
+
+// normalizeRedirectURL lowercases the scheme and host, removes the default port and resolves the dot segments of the path.
+// Returns false if u is not an absolute http(s) URL or has credentials.
+func normalizeRedirectURL(u *url.URL) (*url.URL, bool) {
+	scheme := strings.ToLower(u.Scheme)
+	if (scheme != "http" && scheme != "https") || u.Opaque != "" || u.User != nil {
+		return nil, false
+	}
+
+	hostname := strings.ToLower(u.Hostname())
+	if hostname == "" {
+		return nil, false
+	}
+
+	port := u.Port()
+	if (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+		port = ""
+	}
+
+	host := hostname
+	if port != "" {
+		host = net.JoinHostPort(hostname, port)
+	} else if strings.Contains(hostname, ":") {
+		host = "[" + hostname + "]"
+	}
+
+	cleaned := path.Clean("/" + u.Path)
+	if strings.HasSuffix(u.Path, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+
+	return &url.URL{
+		Scheme:   scheme,
+		Host:     host,
+		Path:     cleaned,
+		RawQuery: u.RawQuery,
+		Fragment: u.Fragment,
+	}, true
+}
+
+// hasPathPrefix reports whether p is prefix or under prefix, so "/app" matches "/app/page" but not "/application".
+func hasPathPrefix(p string, prefix string) bool {
+	if strings.HasSuffix(prefix, "/") {
+		return strings.HasPrefix(p, prefix)
+	}
+	return p == prefix || strings.HasPrefix(p, prefix+"/")
+}
@@ -0,0 +1,96 @@
+package domain_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/okocraft/auth-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedirectAllowlist_NormalizeRedirectURL(t *testing.T) {
+	allowlist := domain.NewRedirectAllowlist([]*url.URL{
+		{Scheme: "http", Host: "localhost:5173"},
+		{Scheme: "https", Host: "example.com", Path: "/app"},
+	})
+
+	tests := []struct {
+		name    string
+		rawURL  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "empty",
+			rawURL: "",
+			want:   "",
+		},
+		{
+			name:   "allowed origin",
+			rawURL: "http://localhost:5173/mypage?tab=1#top",
+			want:   "http://localhost:5173/mypage?tab=1#top",
+		},
+		{
+			name:   "origin without path",
+			rawURL: "http://localhost:5173",
+			want:   "http://localhost:5173/",
+		},
+		{
+			name:   "allowed path prefix",
+			rawURL: "https://example.com/app/page",
+			want:   "https://example.com/app/page",
+		},
+		{
+			name:   "normalized scheme, host, port and path",
+			rawURL: "HTTPS://Example.COM:443/app/./x/../page",
+			want:   "https://example.com/app/page",
+		},
+		{
+			name:    "path outside of the prefix",
+			rawURL:  "https://example.com/application",
+			wantErr: domain.RedirectURLNotAllowedError,
+		},
+		{
+			name:    "path escaping the prefix",
+			rawURL:  "https://example.com/app/../admin",
+			wantErr: domain.RedirectURLNotAllowedError,
+		},
+		{
+			name:    "other origin",
+			rawURL:  "https://evil.example.com/app",
+			wantErr: domain.RedirectURLNotAllowedError,
+		},
+		{
+			name:    "other port",
+			rawURL:  "http://localhost:8080/",
+			wantErr: domain.RedirectURLNotAllowedError,
+		},
+		{
+			name:    "scheme-relative url",
+			rawURL:  "//localhost:5173/",
+			wantErr: domain.RedirectURLNotAllowedError,
+		},
+		{
+			name:    "credentials",
+			rawURL:  "http://user@localhost:5173/",
+			wantErr: domain.RedirectURLNotAllowedError,
+		},
+		{
+			name:    "javascript url",
+			rawURL:  "javascript:alert(1)",
+			wantErr: domain.RedirectURLNotAllowedError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := allowlist.NormalizeRedirectURL(tt.rawURL)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"regexp"
+	"slices"
+	"time"
+)
+
+type RoleID int32
+
+type Role struct {
+	ID          RoleID
+	Name        string
+	Permissions []string
+	CreatedAt   time.Time
+}
+
+// Authorization is the roles assigned to the user and the permissions granted by them.
+//
+// They are embedded in access tokens as the roles claim and the space-delimited scope claim,
+// so both role names and permissions must not contain whitespaces.
+type Authorization struct {
+	Roles       []string
+	Permissions []string
+}
+
+func (a Authorization) HasPermission(permission string) bool {
+	return slices.Contains(a.Permissions, permission)
+}
+
+var (
+	roleNamePattern   = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+	permissionPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.:-]{0,127}$`)
+)
+
+func ValidateRoleName(name string) error {
+	if !roleNamePattern.MatchString(name) {
+		return InvalidRoleNameError
+	}
+	return nil
+}
+
+func ValidatePermission(permission string) error {
+	if !permissionPattern.MatchString(permission) {
+		return InvalidPermissionError
+	}
+	return nil
+}
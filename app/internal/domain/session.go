@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"net"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Session is a login that still has a usable refresh token.
+type Session struct {
+	LoginID         uuid.UUID
+	LoggedInAt      time.Time
+	LastRefreshedAt time.Time
+	IP              net.IP
+	UserAgent       string
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/okocraft/authlib/user"
+)
+
+type User struct {
+	ID        user.ID
+	UUID      uuid.UUID
+	CreatedAt time.Time
+	Status    UserStatus
+}
+
+// UserProfile is the user and the external accounts linked to it, exposed to the user themselves.
+type UserProfile struct {
+	User       User
+	Identities []Identity
+}
+
+type UserStatusType int8
+
+const (
+	UserStatusTypeActive UserStatusType = iota
+	UserStatusTypeSuspended
+	UserStatusTypeBanned
+)
+
+type UserStatus struct {
+	Type UserStatusType
+	// SuspendedUntil is set only when Type is UserStatusTypeSuspended.
+	SuspendedUntil time.Time
+	Reason         string
+}
+
+// Check returns AccountSuspendedError if the user is banned or suspended at the time.
+// A suspension ends by itself when SuspendedUntil has passed.
+func (s UserStatus) Check(now time.Time) error {
+	switch s.Type {
+	case UserStatusTypeBanned:
+		return AccountSuspendedError
+	case UserStatusTypeSuspended:
+		if now.Before(s.SuspendedUntil) {
+			return AccountSuspendedError
+		}
+	}
+	return nil
+}
+
+const UserStatusReasonMaxLength = 512
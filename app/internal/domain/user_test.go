@@ -0,0 +1,49 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/okocraft/auth-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserStatus_Check(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		status  domain.UserStatus
+		wantErr error
+	}{
+		{
+			name:   "active",
+			status: domain.UserStatus{Type: domain.UserStatusTypeActive},
+		},
+		{
+			name:    "banned",
+			status:  domain.UserStatus{Type: domain.UserStatusTypeBanned, Reason: "griefing"},
+			wantErr: domain.AccountSuspendedError,
+		},
+		{
+			name:    "suspended",
+			status:  domain.UserStatus{Type: domain.UserStatusTypeSuspended, SuspendedUntil: now.Add(time.Hour)},
+			wantErr: domain.AccountSuspendedError,
+		},
+		{
+			name:   "suspension ended",
+			status: domain.UserStatus{Type: domain.UserStatusTypeSuspended, SuspendedUntil: now},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.status.Check(now)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package encryption
+
+import (
+	"errors"
+
+	"github.com/okocraft/authlib/encrypt"
+)
+
+// NewAESKeyringEncrypter creates an encrypt.Encrypter that encrypts data with the current key and decrypts data encrypted with any of the keys.
+//
+// Since AES-GCM authenticates the ciphertext, trying the keys in order never returns garbage decrypted with a wrong key.
+func NewAESKeyringEncrypter(current []byte, retired ...[]byte) (encrypt.Encrypter, error) {
+	encrypters := make([]encrypt.Encrypter, 0, len(retired)+1)
+	for _, key := range append([][]byte{current}, retired...) {
+		encrypter, err := encrypt.NewAESEncrypter(key)
+		if err != nil {
+			return nil, err
+		}
+		encrypters = append(encrypters, encrypter)
+	}
+	return &keyringEncrypter{encrypters: encrypters}, nil
+}
+
+type keyringEncrypter struct {
+	encrypters []encrypt.Encrypter
+}
+
+func (e keyringEncrypter) Encrypt(data []byte) ([]byte, error) {
+	return e.encrypters[0].Encrypt(data)
+}
+
+func (e keyringEncrypter) Decrypt(cipherData []byte) ([]byte, error) {
+	var errs []error
+	for _, encrypter := range e.encrypters {
+		decrypted, err := encrypter.Decrypt(cipherData)
+		if err == nil {
+			return decrypted, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
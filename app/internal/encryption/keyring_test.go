@@ -0,0 +1,80 @@
+package encryption_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/okocraft/auth-service/internal/encryption"
+	"github.com/okocraft/authlib/encrypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRandomKey(t *testing.T) []byte {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return key
+}
+
+func TestKeyringEncrypter_Decrypt(t *testing.T) {
+	current := newRandomKey(t)
+	retired := newRandomKey(t)
+
+	keyring, err := encryption.NewAESKeyringEncrypter(current, retired)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		key     []byte
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success: current key",
+			key:     current,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success: retired key",
+			key:     retired,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error: unknown key",
+			key:     newRandomKey(t),
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encrypter, err := encrypt.NewAESEncrypter(tt.key)
+			require.NoError(t, err)
+
+			encrypted, err := encrypter.Encrypt([]byte("code verifier"))
+			require.NoError(t, err)
+
+			got, err := keyring.Decrypt(encrypted)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+			assert.Equal(t, []byte("code verifier"), got)
+		})
+	}
+}
+
+func TestKeyringEncrypter_Encrypt(t *testing.T) {
+	current := newRandomKey(t)
+
+	keyring, err := encryption.NewAESKeyringEncrypter(current, newRandomKey(t))
+	require.NoError(t, err)
+
+	encrypted, err := keyring.Encrypt([]byte("code verifier"))
+	require.NoError(t, err)
+
+	encrypter, err := encrypt.NewAESEncrypter(current)
+	require.NoError(t, err)
+
+	got, err := encrypter.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("code verifier"), got)
+}
@@ -4,22 +4,69 @@
 package oapi
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
-// Defines values for GoogleLoginResult.
 const (
-	GoogleLoginResultAlreadyLinked    GoogleLoginResult = "already_linked"
-	GoogleLoginResultInternalError    GoogleLoginResult = "internal_error"
-	GoogleLoginResultInvalidToken     GoogleLoginResult = "invalid_token"
-	GoogleLoginResultLoginKeyNotFound GoogleLoginResult = "login_key_not_found"
-	GoogleLoginResultNotEnabled       GoogleLoginResult = "not_enabled"
-	GoogleLoginResultSuccess          GoogleLoginResult = "success"
-	GoogleLoginResultUserNotFound     GoogleLoginResult = "user_not_found"
+	AdminApiKeyAuthScopes   = "AdminApiKeyAuth.Scopes"
+	BearerAuthScopes        = "BearerAuth.Scopes"
+	ServiceApiKeyAuthScopes = "ServiceApiKeyAuth.Scopes"
+)
+
+// Defines values for AccessLogAction.
+const (
+	AccessLogActionFirstLogin                AccessLogAction = "first_login"
+	AccessLogActionLogin                     AccessLogAction = "login"
+	AccessLogActionLoginDenied               AccessLogAction = "login_denied"
+	AccessLogActionLoginFailed               AccessLogAction = "login_failed"
+	AccessLogActionLogout                    AccessLogAction = "logout"
+	AccessLogActionRefreshDenied             AccessLogAction = "refresh_denied"
+	AccessLogActionRefreshFailed             AccessLogAction = "refresh_failed"
+	AccessLogActionRefreshToken              AccessLogAction = "refresh_token"
+	AccessLogActionRefreshTokenReuseDetected AccessLogAction = "refresh_token_reuse_detected"
+	AccessLogActionRevokedByAdmin            AccessLogAction = "revoked_by_admin"
+	AccessLogActionSessionRevoked            AccessLogAction = "session_revoked"
+	AccessLogActionTokenRevoked              AccessLogAction = "token_revoked"
+)
+
+// Defines values for AccessLogFailureReason.
+const (
+	AccessLogFailureReasonAccountSuspended     AccessLogFailureReason = "account_suspended"
+	AccessLogFailureReasonCsrfMismatch         AccessLogFailureReason = "csrf_mismatch"
+	AccessLogFailureReasonInvalidRefreshToken  AccessLogFailureReason = "invalid_refresh_token"
+	AccessLogFailureReasonInvalidState         AccessLogFailureReason = "invalid_state"
+	AccessLogFailureReasonLoginKeyExpired      AccessLogFailureReason = "login_key_expired"
+	AccessLogFailureReasonLoginKeyNotFound     AccessLogFailureReason = "login_key_not_found"
+	AccessLogFailureReasonProviderError        AccessLogFailureReason = "provider_error"
+	AccessLogFailureReasonSubjectAlreadyLinked AccessLogFailureReason = "subject_already_linked"
+	AccessLogFailureReasonUnknownSubject       AccessLogFailureReason = "unknown_subject"
+)
+
+// Defines values for OAuthLoginResult.
+const (
+	OAuthLoginResultAccountSuspended OAuthLoginResult = "account_suspended"
+	OAuthLoginResultAlreadyLinked    OAuthLoginResult = "already_linked"
+	OAuthLoginResultInternalError    OAuthLoginResult = "internal_error"
+	OAuthLoginResultInvalidToken     OAuthLoginResult = "invalid_token"
+	OAuthLoginResultLoginKeyExpired  OAuthLoginResult = "login_key_expired"
+	OAuthLoginResultLoginKeyNotFound OAuthLoginResult = "login_key_not_found"
+	OAuthLoginResultNotEnabled       OAuthLoginResult = "not_enabled"
+	OAuthLoginResultSuccess          OAuthLoginResult = "success"
+	OAuthLoginResultUserNotFound     OAuthLoginResult = "user_not_found"
+)
+
+// Defines values for UserStatusType.
+const (
+	UserStatusTypeActive    UserStatusType = "active"
+	UserStatusTypeBanned    UserStatusType = "banned"
+	UserStatusTypeSuspended UserStatusType = "suspended"
 )
 
 // Defines values for Versions.
@@ -27,110 +74,1223 @@ const (
 	VersionsV10 Versions = "v1.0"
 )
 
-// AccessTokenResponse defines model for AccessTokenResponse.
-type AccessTokenResponse struct {
-	// AccessToken the access token
-	AccessToken string `json:"access_token"`
-}
+// AccessLog defines model for AccessLog.
+type AccessLog struct {
+	Action AccessLogAction `json:"action"`
+
+	// CreatedAt the time when the event occurred
+	CreatedAt time.Time `json:"created_at"`
+
+	// Id the id of the event, usable as the before cursor
+	Id int64 `json:"id"`
+
+	// Ip the IP address of the client
+	Ip string `json:"ip"`
+
+	// Reason the reason of the failure, absent if the event is not a failure
+	Reason *AccessLogFailureReason `json:"reason,omitempty"`
+
+	// SessionId the id of the login (session) the event belongs to, absent if the event is not tied to a login
+	SessionId *openapi_types.UUID `json:"session_id,omitempty"`
+
+	// UserAgent the user agent of the client
+	UserAgent string `json:"user_agent"`
+}
+
+// AccessLogAction defines model for AccessLogAction.
+type AccessLogAction string
+
+// AccessLogFailureReason defines model for AccessLogFailureReason.
+type AccessLogFailureReason string
+
+// AccessLogListResponse defines model for AccessLogListResponse.
+type AccessLogListResponse struct {
+	AccessLogs []AccessLog `json:"access_logs"`
+
+	// NextBefore the cursor to pass as before to get the next page, absent on the last page
+	NextBefore *int64 `json:"next_before,omitempty"`
+}
+
+// AccessTokenResponse defines model for AccessTokenResponse.
+type AccessTokenResponse struct {
+	// AccessToken the access token
+	AccessToken string `json:"access_token"`
+}
+
+// AdminUserResponse defines model for AdminUserResponse.
+type AdminUserResponse struct {
+	// CreatedAt the time when the user was created
+	CreatedAt  time.Time      `json:"created_at"`
+	Identities []UserIdentity `json:"identities"`
+
+	// Roles the names of the roles assigned to the user
+	Roles  []string        `json:"roles"`
+	Status AdminUserStatus `json:"status"`
+
+	// Uuid the UUID of the user
+	Uuid openapi_types.UUID `json:"uuid"`
+}
+
+// AdminUserStatus defines model for AdminUserStatus.
+type AdminUserStatus struct {
+	// Reason the reason shown to operators
+	Reason *string `json:"reason,omitempty"`
+
+	// SuspendedUntil the time when the suspension ends, required if the type is suspended
+	SuspendedUntil *time.Time     `json:"suspended_until,omitempty"`
+	Type           UserStatusType `json:"type"`
+}
+
+// FailedAccessLog defines model for FailedAccessLog.
+type FailedAccessLog struct {
+	Action AccessLogAction `json:"action"`
+
+	// CreatedAt the time when the event occurred
+	CreatedAt time.Time `json:"created_at"`
+
+	// Id the id of the event, usable as the before cursor
+	Id int64 `json:"id"`
+
+	// Ip the IP address of the client
+	Ip string `json:"ip"`
+
+	// Reason the reason of the failure, absent if the event is not a failure
+	Reason *AccessLogFailureReason `json:"reason,omitempty"`
+
+	// SessionId the id of the login (session) the event belongs to, absent if the event is not tied to a login
+	SessionId *openapi_types.UUID `json:"session_id,omitempty"`
+
+	// UserAgent the user agent of the client
+	UserAgent string `json:"user_agent"`
+
+	// UserUuid the UUID of the user, absent if the attempt cannot be attributed to a user
+	UserUuid *openapi_types.UUID `json:"user_uuid,omitempty"`
+}
+
+// FailedAccessLogListResponse defines model for FailedAccessLogListResponse.
+type FailedAccessLogListResponse struct {
+	AccessLogs []FailedAccessLog `json:"access_logs"`
+
+	// NextBefore the cursor to pass as before to get the next page, absent on the last page
+	NextBefore *int64 `json:"next_before,omitempty"`
+}
+
+// IntrospectionRequest defines model for IntrospectionRequest.
+type IntrospectionRequest struct {
+	// Token the access token to introspect
+	Token string `json:"token"`
+
+	// TokenTypeHint the type of the token; only access_token is supported
+	TokenTypeHint *string `json:"token_type_hint,omitempty"`
+}
+
+// IntrospectionResponse defines model for IntrospectionResponse.
+type IntrospectionResponse struct {
+	// Active whether the token is active
+	Active bool `json:"active"`
+
+	// Exp the time when the token expires, in seconds since the epoch
+	Exp *int64 `json:"exp,omitempty"`
+
+	// Iat the time when the token was issued, in seconds since the epoch
+	Iat *int64 `json:"iat,omitempty"`
+
+	// Jti the id of the token
+	Jti *string `json:"jti,omitempty"`
+
+	// Roles the roles of the user when the token was issued
+	Roles *[]string `json:"roles,omitempty"`
+
+	// Scope the space-delimited permissions granted to the token
+	Scope *string `json:"scope,omitempty"`
+
+	// Sub the UUID of the user
+	Sub *openapi_types.UUID `json:"sub,omitempty"`
+
+	// TokenType the type of the token
+	TokenType *string `json:"token_type,omitempty"`
+}
+
+// IssueLoginKeyRequest defines model for IssueLoginKeyRequest.
+type IssueLoginKeyRequest struct {
+	// Uuid the Minecraft UUID of the player
+	Uuid openapi_types.UUID `json:"uuid"`
+}
+
+// IssueLoginKeyResponse defines model for IssueLoginKeyResponse.
+type IssueLoginKeyResponse struct {
+	// ExpiresAt the time when the login key expires
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// LoginKey the login key for linking the account
+	LoginKey string `json:"login_key"`
+}
+
+// JWK the public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	// Alg the algorithm of the key
+	Alg string `json:"alg"`
+
+	// Crv the curve of the key
+	Crv string `json:"crv"`
+
+	// Kid the key id, which matches the kid header of the signed token
+	Kid string `json:"kid"`
+
+	// Kty the key type
+	Kty string `json:"kty"`
+
+	// Use the intended use of the key
+	Use string `json:"use"`
+
+	// X the public key
+	X string `json:"x"`
+}
+
+// JWKS the JSON Web Key Set (RFC 7517)
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// OAuthLinkRequest defines model for OAuthLinkRequest.
+type OAuthLinkRequest struct {
+	// LoginKey the login key
+	LoginKey string `json:"login_key"`
+}
+
+// OAuthLoginRequest defines model for OAuthLoginRequest.
+type OAuthLoginRequest struct {
+	// CurrentUrl the url of the page currently being viewed, which must be under one of the allowed redirect urls
+	CurrentUrl string `json:"current_url"`
+}
+
+// OAuthLoginResponse defines model for OAuthLoginResponse.
+type OAuthLoginResponse struct {
+	// RedirectUrl the provider's login page URL
+	RedirectUrl string `json:"redirect_url"`
+}
+
+// OAuthLoginResult defines model for OAuthLoginResult.
+type OAuthLoginResult string
+
+// PutRoleRequest defines model for PutRoleRequest.
+type PutRoleRequest struct {
+	// Permissions the permissions to grant to the role
+	Permissions []string `json:"permissions"`
+}
+
+// RevocationRequest defines model for RevocationRequest.
+type RevocationRequest struct {
+	// Token the access token or the refresh token to revoke
+	Token string `json:"token"`
+
+	// TokenTypeHint the type of the token, access_token or refresh_token
+	TokenTypeHint *string `json:"token_type_hint,omitempty"`
+}
+
+// Role defines model for Role.
+type Role struct {
+	// CreatedAt the time when the role was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// Name the name of the role
+	Name string `json:"name"`
+
+	// Permissions the permissions granted by the role
+	Permissions []string `json:"permissions"`
+}
+
+// RoleListResponse defines model for RoleListResponse.
+type RoleListResponse struct {
+	Roles []Role `json:"roles"`
+}
+
+// Session defines model for Session.
+type Session struct {
+	// Current whether the session is the one making this request
+	Current bool `json:"current"`
+
+	// Id the id of the session
+	Id openapi_types.UUID `json:"id"`
+
+	// Ip the IP address of the last access
+	Ip string `json:"ip"`
+
+	// LastRefreshedAt the time when the access token was last refreshed
+	LastRefreshedAt time.Time `json:"last_refreshed_at"`
+
+	// LoggedInAt the time when the user logged in
+	LoggedInAt time.Time `json:"logged_in_at"`
+
+	// UserAgent the user agent of the last access
+	UserAgent string `json:"user_agent"`
+}
+
+// SessionListResponse defines model for SessionListResponse.
+type SessionListResponse struct {
+	Sessions []Session `json:"sessions"`
+}
+
+// UserIdentity the external account linked to the user
+type UserIdentity struct {
+	// LinkedAt the time when the account was linked
+	LinkedAt time.Time `json:"linked_at"`
+
+	// Provider the name of the identity provider, e.g. google
+	Provider string `json:"provider"`
+
+	// Subject the subject of the account at the provider
+	Subject string `json:"subject"`
+}
+
+// UserResponse defines model for UserResponse.
+type UserResponse struct {
+	// CreatedAt the time when the user was created
+	CreatedAt  time.Time      `json:"created_at"`
+	Identities []UserIdentity `json:"identities"`
+
+	// Uuid the public UUID of the user, which is also the sub claim of access tokens
+	Uuid openapi_types.UUID `json:"uuid"`
+}
+
+// UserStatusType defines model for UserStatusType.
+type UserStatusType string
+
+// Versions defines model for Versions.
+type Versions string
+
+// AdminGetFailedAccessLogsParams defines parameters for AdminGetFailedAccessLogs.
+type AdminGetFailedAccessLogsParams struct {
+	// Limit the maximum number of the access logs, up to 100
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Before returns the access logs older than the event of this id
+	Before *int64 `form:"before,omitempty" json:"before,omitempty"`
+
+	// Ip returns only the attempts from this IP address
+	Ip *string `form:"ip,omitempty" json:"ip,omitempty"`
+}
+
+// AdminGetUserAccessLogsParams defines parameters for AdminGetUserAccessLogs.
+type AdminGetUserAccessLogsParams struct {
+	// Limit the maximum number of the access logs, up to 100
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Before returns the access logs older than the event of this id
+	Before *int64 `form:"before,omitempty" json:"before,omitempty"`
+
+	// SessionId returns only the access logs of this login (session)
+	SessionId *openapi_types.UUID `form:"session_id,omitempty" json:"session_id,omitempty"`
+}
+
+// LogoutParams defines parameters for Logout.
+type LogoutParams struct {
+	XCSRFToken   *string `json:"X-CSRF-Token,omitempty"`
+	RefreshToken string  `form:"refresh_token" json:"refresh_token"`
+}
+
+// RefreshAccessTokenParams defines parameters for RefreshAccessToken.
+type RefreshAccessTokenParams struct {
+	XCSRFToken   *string `json:"X-CSRF-Token,omitempty"`
+	RefreshToken string  `form:"refresh_token" json:"refresh_token"`
+}
+
+// AdminPutRoleJSONRequestBody defines body for AdminPutRole for application/json ContentType.
+type AdminPutRoleJSONRequestBody = PutRoleRequest
+
+// AdminUpdateUserStatusJSONRequestBody defines body for AdminUpdateUserStatus for application/json ContentType.
+type AdminUpdateUserStatusJSONRequestBody = AdminUserStatus
+
+// IntrospectTokenFormdataRequestBody defines body for IntrospectToken for application/x-www-form-urlencoded ContentType.
+type IntrospectTokenFormdataRequestBody = IntrospectionRequest
+
+// LinkWithOAuthProviderJSONRequestBody defines body for LinkWithOAuthProvider for application/json ContentType.
+type LinkWithOAuthProviderJSONRequestBody = OAuthLinkRequest
+
+// LoginWithOAuthProviderJSONRequestBody defines body for LoginWithOAuthProvider for application/json ContentType.
+type LoginWithOAuthProviderJSONRequestBody = OAuthLoginRequest
+
+// RevokeTokenFormdataRequestBody defines body for RevokeToken for application/x-www-form-urlencoded ContentType.
+type RevokeTokenFormdataRequestBody = RevocationRequest
+
+// IssueLoginKeyJSONRequestBody defines body for IssueLoginKey for application/json ContentType.
+type IssueLoginKeyJSONRequestBody = IssueLoginKeyRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+
+	// (GET /.well-known/jwks.json)
+	GetJWKS(w http.ResponseWriter, r *http.Request)
+
+	// (GET /admin/failed-access-logs)
+	AdminGetFailedAccessLogs(w http.ResponseWriter, r *http.Request, params AdminGetFailedAccessLogsParams)
+
+	// (GET /admin/identities/{provider}/{subject})
+	AdminGetUserByIdentity(w http.ResponseWriter, r *http.Request, provider string, subject string)
+
+	// (GET /admin/roles)
+	AdminGetRoles(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /admin/roles/{role_name})
+	AdminDeleteRole(w http.ResponseWriter, r *http.Request, roleName string)
+
+	// (PUT /admin/roles/{role_name})
+	AdminPutRole(w http.ResponseWriter, r *http.Request, roleName string)
+
+	// (DELETE /admin/roles/{role_name}/permissions/{permission})
+	AdminRevokePermission(w http.ResponseWriter, r *http.Request, roleName string, permission string)
+
+	// (GET /admin/users/{user_uuid})
+	AdminGetUser(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID)
+
+	// (GET /admin/users/{user_uuid}/access-logs)
+	AdminGetUserAccessLogs(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID, params AdminGetUserAccessLogsParams)
+
+	// (DELETE /admin/users/{user_uuid}/identities/{provider}/{subject})
+	AdminUnlinkIdentity(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID, provider string, subject string)
+
+	// (POST /admin/users/{user_uuid}/login-key)
+	AdminIssueLoginKey(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID)
+
+	// (DELETE /admin/users/{user_uuid}/roles/{role_name})
+	AdminUnassignRole(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID, roleName string)
+
+	// (PUT /admin/users/{user_uuid}/roles/{role_name})
+	AdminAssignRole(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID, roleName string)
+
+	// (DELETE /admin/users/{user_uuid}/sessions)
+	AdminRevokeUserSessions(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID)
+
+	// (GET /admin/users/{user_uuid}/sessions)
+	AdminGetUserSessions(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID)
+
+	// (DELETE /admin/users/{user_uuid}/sessions/{session_id})
+	AdminRevokeUserSession(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID, sessionId openapi_types.UUID)
+
+	// (PUT /admin/users/{user_uuid}/status)
+	AdminUpdateUserStatus(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID)
+
+	// (POST /auth/introspect)
+	IntrospectToken(w http.ResponseWriter, r *http.Request)
+
+	// (POST /auth/logout)
+	Logout(w http.ResponseWriter, r *http.Request, params LogoutParams)
+
+	// (GET /auth/oauth/{provider}/callback)
+	CallbackFromOAuthProvider(w http.ResponseWriter, r *http.Request, provider string)
+
+	// (POST /auth/oauth/{provider}/link)
+	LinkWithOAuthProvider(w http.ResponseWriter, r *http.Request, provider string)
+
+	// (POST /auth/oauth/{provider}/login)
+	LoginWithOAuthProvider(w http.ResponseWriter, r *http.Request, provider string)
+
+	// (POST /auth/refresh)
+	RefreshAccessToken(w http.ResponseWriter, r *http.Request, params RefreshAccessTokenParams)
+
+	// (POST /auth/revoke)
+	RevokeToken(w http.ResponseWriter, r *http.Request)
+
+	// (GET /auth/sessions)
+	GetSessions(w http.ResponseWriter, r *http.Request)
+
+	// (POST /auth/sessions/revoke-others)
+	RevokeOtherSessions(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /auth/sessions/{session_id})
+	RevokeSession(w http.ResponseWriter, r *http.Request, sessionId openapi_types.UUID)
+
+	// (POST /service/login-key)
+	IssueLoginKey(w http.ResponseWriter, r *http.Request)
+
+	// (GET /users/me)
+	GetCurrentUser(w http.ResponseWriter, r *http.Request)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// (GET /.well-known/jwks.json)
+func (_ Unimplemented) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /admin/failed-access-logs)
+func (_ Unimplemented) AdminGetFailedAccessLogs(w http.ResponseWriter, r *http.Request, params AdminGetFailedAccessLogsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /admin/identities/{provider}/{subject})
+func (_ Unimplemented) AdminGetUserByIdentity(w http.ResponseWriter, r *http.Request, provider string, subject string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /admin/roles)
+func (_ Unimplemented) AdminGetRoles(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /admin/roles/{role_name})
+func (_ Unimplemented) AdminDeleteRole(w http.ResponseWriter, r *http.Request, roleName string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /admin/roles/{role_name})
+func (_ Unimplemented) AdminPutRole(w http.ResponseWriter, r *http.Request, roleName string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /admin/roles/{role_name}/permissions/{permission})
+func (_ Unimplemented) AdminRevokePermission(w http.ResponseWriter, r *http.Request, roleName string, permission string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /admin/users/{user_uuid})
+func (_ Unimplemented) AdminGetUser(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /admin/users/{user_uuid}/access-logs)
+func (_ Unimplemented) AdminGetUserAccessLogs(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID, params AdminGetUserAccessLogsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /admin/users/{user_uuid}/identities/{provider}/{subject})
+func (_ Unimplemented) AdminUnlinkIdentity(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID, provider string, subject string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /admin/users/{user_uuid}/login-key)
+func (_ Unimplemented) AdminIssueLoginKey(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /admin/users/{user_uuid}/roles/{role_name})
+func (_ Unimplemented) AdminUnassignRole(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID, roleName string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /admin/users/{user_uuid}/roles/{role_name})
+func (_ Unimplemented) AdminAssignRole(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID, roleName string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /admin/users/{user_uuid}/sessions)
+func (_ Unimplemented) AdminRevokeUserSessions(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /admin/users/{user_uuid}/sessions)
+func (_ Unimplemented) AdminGetUserSessions(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /admin/users/{user_uuid}/sessions/{session_id})
+func (_ Unimplemented) AdminRevokeUserSession(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID, sessionId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /admin/users/{user_uuid}/status)
+func (_ Unimplemented) AdminUpdateUserStatus(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /auth/introspect)
+func (_ Unimplemented) IntrospectToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /auth/logout)
+func (_ Unimplemented) Logout(w http.ResponseWriter, r *http.Request, params LogoutParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /auth/oauth/{provider}/callback)
+func (_ Unimplemented) CallbackFromOAuthProvider(w http.ResponseWriter, r *http.Request, provider string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /auth/oauth/{provider}/link)
+func (_ Unimplemented) LinkWithOAuthProvider(w http.ResponseWriter, r *http.Request, provider string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /auth/oauth/{provider}/login)
+func (_ Unimplemented) LoginWithOAuthProvider(w http.ResponseWriter, r *http.Request, provider string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /auth/refresh)
+func (_ Unimplemented) RefreshAccessToken(w http.ResponseWriter, r *http.Request, params RefreshAccessTokenParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /auth/revoke)
+func (_ Unimplemented) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /auth/sessions)
+func (_ Unimplemented) GetSessions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /auth/sessions/revoke-others)
+func (_ Unimplemented) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /auth/sessions/{session_id})
+func (_ Unimplemented) RevokeSession(w http.ResponseWriter, r *http.Request, sessionId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /service/login-key)
+func (_ Unimplemented) IssueLoginKey(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /users/me)
+func (_ Unimplemented) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetJWKS operation middleware
+func (siw *ServerInterfaceWrapper) GetJWKS(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetJWKS(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminGetFailedAccessLogs operation middleware
+func (siw *ServerInterfaceWrapper) AdminGetFailedAccessLogs(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminGetFailedAccessLogsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "before", r.URL.Query(), &params.Before)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "ip" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "ip", r.URL.Query(), &params.Ip)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ip", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminGetFailedAccessLogs(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminGetUserByIdentity operation middleware
+func (siw *ServerInterfaceWrapper) AdminGetUserByIdentity(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", chi.URLParam(r, "provider"), &provider, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "subject" -------------
+	var subject string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subject", chi.URLParam(r, "subject"), &subject, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subject", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminGetUserByIdentity(w, r, provider, subject)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminGetRoles operation middleware
+func (siw *ServerInterfaceWrapper) AdminGetRoles(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminGetRoles(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminDeleteRole operation middleware
+func (siw *ServerInterfaceWrapper) AdminDeleteRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "role_name" -------------
+	var roleName string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "role_name", chi.URLParam(r, "role_name"), &roleName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminDeleteRole(w, r, roleName)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminPutRole operation middleware
+func (siw *ServerInterfaceWrapper) AdminPutRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "role_name" -------------
+	var roleName string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "role_name", chi.URLParam(r, "role_name"), &roleName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminPutRole(w, r, roleName)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminRevokePermission operation middleware
+func (siw *ServerInterfaceWrapper) AdminRevokePermission(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "role_name" -------------
+	var roleName string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "role_name", chi.URLParam(r, "role_name"), &roleName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_name", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "permission" -------------
+	var permission string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "permission", chi.URLParam(r, "permission"), &permission, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "permission", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminRevokePermission(w, r, roleName, permission)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminGetUser operation middleware
+func (siw *ServerInterfaceWrapper) AdminGetUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_uuid" -------------
+	var userUuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_uuid", chi.URLParam(r, "user_uuid"), &userUuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_uuid", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminGetUser(w, r, userUuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminGetUserAccessLogs operation middleware
+func (siw *ServerInterfaceWrapper) AdminGetUserAccessLogs(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_uuid" -------------
+	var userUuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_uuid", chi.URLParam(r, "user_uuid"), &userUuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_uuid", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminGetUserAccessLogsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "before", r.URL.Query(), &params.Before)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "session_id" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "session_id", r.URL.Query(), &params.SessionId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "session_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminGetUserAccessLogs(w, r, userUuid, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminUnlinkIdentity operation middleware
+func (siw *ServerInterfaceWrapper) AdminUnlinkIdentity(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_uuid" -------------
+	var userUuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_uuid", chi.URLParam(r, "user_uuid"), &userUuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", chi.URLParam(r, "provider"), &provider, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "subject" -------------
+	var subject string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subject", chi.URLParam(r, "subject"), &subject, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subject", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminUnlinkIdentity(w, r, userUuid, provider, subject)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminIssueLoginKey operation middleware
+func (siw *ServerInterfaceWrapper) AdminIssueLoginKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_uuid" -------------
+	var userUuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_uuid", chi.URLParam(r, "user_uuid"), &userUuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_uuid", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminIssueLoginKey(w, r, userUuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminUnassignRole operation middleware
+func (siw *ServerInterfaceWrapper) AdminUnassignRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_uuid" -------------
+	var userUuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_uuid", chi.URLParam(r, "user_uuid"), &userUuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "role_name" -------------
+	var roleName string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "role_name", chi.URLParam(r, "role_name"), &roleName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminUnassignRole(w, r, userUuid, roleName)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminAssignRole operation middleware
+func (siw *ServerInterfaceWrapper) AdminAssignRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_uuid" -------------
+	var userUuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_uuid", chi.URLParam(r, "user_uuid"), &userUuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "role_name" -------------
+	var roleName string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "role_name", chi.URLParam(r, "role_name"), &roleName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role_name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminAssignRole(w, r, userUuid, roleName)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AdminRevokeUserSessions operation middleware
+func (siw *ServerInterfaceWrapper) AdminRevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_uuid" -------------
+	var userUuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_uuid", chi.URLParam(r, "user_uuid"), &userUuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_uuid", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminApiKeyAuthScopes, []string{})
 
-// GoogleFirstLoginRequest defines model for GoogleFirstLoginRequest.
-type GoogleFirstLoginRequest struct {
-	// LoginKey the login key
-	LoginKey string `json:"login_key"`
-}
+	r = r.WithContext(ctx)
 
-// GoogleLoginRequest defines model for GoogleLoginRequest.
-type GoogleLoginRequest struct {
-	// CurrentUrl the url of the page currently being viewed
-	CurrentUrl string `json:"current_url"`
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminRevokeUserSessions(w, r, userUuid)
+	}))
 
-// GoogleLoginResponse defines model for GoogleLoginResponse.
-type GoogleLoginResponse struct {
-	// RedirectUrl the Google's login page URL
-	RedirectUrl string `json:"redirect_url"`
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// GoogleLoginResult defines model for GoogleLoginResult.
-type GoogleLoginResult string
+// AdminGetUserSessions operation middleware
+func (siw *ServerInterfaceWrapper) AdminGetUserSessions(w http.ResponseWriter, r *http.Request) {
 
-// Versions defines model for Versions.
-type Versions string
+	var err error
 
-// LogoutParams defines parameters for Logout.
-type LogoutParams struct {
-	XCSRFToken   *string `json:"X-CSRF-Token,omitempty"`
-	RefreshToken string  `form:"refresh_token" json:"refresh_token"`
-}
+	// ------------- Path parameter "user_uuid" -------------
+	var userUuid openapi_types.UUID
 
-// RefreshAccessTokenParams defines parameters for RefreshAccessToken.
-type RefreshAccessTokenParams struct {
-	XCSRFToken   *string `json:"X-CSRF-Token,omitempty"`
-	RefreshToken string  `form:"refresh_token" json:"refresh_token"`
+	err = runtime.BindStyledParameterWithOptions("simple", "user_uuid", chi.URLParam(r, "user_uuid"), &userUuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_uuid", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminGetUserSessions(w, r, userUuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// LinkWithGoogleJSONRequestBody defines body for LinkWithGoogle for application/json ContentType.
-type LinkWithGoogleJSONRequestBody = GoogleFirstLoginRequest
+// AdminRevokeUserSession operation middleware
+func (siw *ServerInterfaceWrapper) AdminRevokeUserSession(w http.ResponseWriter, r *http.Request) {
 
-// LoginWithGoogleJSONRequestBody defines body for LoginWithGoogle for application/json ContentType.
-type LoginWithGoogleJSONRequestBody = GoogleLoginRequest
+	var err error
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
+	// ------------- Path parameter "user_uuid" -------------
+	var userUuid openapi_types.UUID
 
-	// (POST /auth/logout)
-	Logout(w http.ResponseWriter, r *http.Request, params LogoutParams)
+	err = runtime.BindStyledParameterWithOptions("simple", "user_uuid", chi.URLParam(r, "user_uuid"), &userUuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_uuid", Err: err})
+		return
+	}
 
-	// (GET /auth/oauth/google/callback)
-	CallbackFromGoogle(w http.ResponseWriter, r *http.Request)
+	// ------------- Path parameter "session_id" -------------
+	var sessionId openapi_types.UUID
 
-	// (POST /auth/oauth/google/link)
-	LinkWithGoogle(w http.ResponseWriter, r *http.Request)
+	err = runtime.BindStyledParameterWithOptions("simple", "session_id", chi.URLParam(r, "session_id"), &sessionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "session_id", Err: err})
+		return
+	}
 
-	// (POST /auth/oauth/google/login)
-	LoginWithGoogle(w http.ResponseWriter, r *http.Request)
+	ctx := r.Context()
 
-	// (POST /auth/refresh)
-	RefreshAccessToken(w http.ResponseWriter, r *http.Request, params RefreshAccessTokenParams)
-}
+	ctx = context.WithValue(ctx, AdminApiKeyAuthScopes, []string{})
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+	r = r.WithContext(ctx)
 
-type Unimplemented struct{}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminRevokeUserSession(w, r, userUuid, sessionId)
+	}))
 
-// (POST /auth/logout)
-func (_ Unimplemented) Logout(w http.ResponseWriter, r *http.Request, params LogoutParams) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// (GET /auth/oauth/google/callback)
-func (_ Unimplemented) CallbackFromGoogle(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+	handler.ServeHTTP(w, r)
 }
 
-// (POST /auth/oauth/google/link)
-func (_ Unimplemented) LinkWithGoogle(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+// AdminUpdateUserStatus operation middleware
+func (siw *ServerInterfaceWrapper) AdminUpdateUserStatus(w http.ResponseWriter, r *http.Request) {
 
-// (POST /auth/oauth/google/login)
-func (_ Unimplemented) LoginWithGoogle(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	var err error
 
-// (POST /auth/refresh)
-func (_ Unimplemented) RefreshAccessToken(w http.ResponseWriter, r *http.Request, params RefreshAccessTokenParams) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	// ------------- Path parameter "user_uuid" -------------
+	var userUuid openapi_types.UUID
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+	err = runtime.BindStyledParameterWithOptions("simple", "user_uuid", chi.URLParam(r, "user_uuid"), &userUuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_uuid", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, AdminApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminUpdateUserStatus(w, r, userUuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// IntrospectToken operation middleware
+func (siw *ServerInterfaceWrapper) IntrospectToken(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ServiceApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.IntrospectToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
 
 // Logout operation middleware
 func (siw *ServerInterfaceWrapper) Logout(w http.ResponseWriter, r *http.Request) {
@@ -190,11 +1350,22 @@ func (siw *ServerInterfaceWrapper) Logout(w http.ResponseWriter, r *http.Request
 	handler.ServeHTTP(w, r)
 }
 
-// CallbackFromGoogle operation middleware
-func (siw *ServerInterfaceWrapper) CallbackFromGoogle(w http.ResponseWriter, r *http.Request) {
+// CallbackFromOAuthProvider operation middleware
+func (siw *ServerInterfaceWrapper) CallbackFromOAuthProvider(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", chi.URLParam(r, "provider"), &provider, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CallbackFromGoogle(w, r)
+		siw.Handler.CallbackFromOAuthProvider(w, r, provider)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -204,11 +1375,22 @@ func (siw *ServerInterfaceWrapper) CallbackFromGoogle(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// LinkWithGoogle operation middleware
-func (siw *ServerInterfaceWrapper) LinkWithGoogle(w http.ResponseWriter, r *http.Request) {
+// LinkWithOAuthProvider operation middleware
+func (siw *ServerInterfaceWrapper) LinkWithOAuthProvider(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", chi.URLParam(r, "provider"), &provider, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LinkWithGoogle(w, r)
+		siw.Handler.LinkWithOAuthProvider(w, r, provider)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -218,11 +1400,22 @@ func (siw *ServerInterfaceWrapper) LinkWithGoogle(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// LoginWithGoogle operation middleware
-func (siw *ServerInterfaceWrapper) LoginWithGoogle(w http.ResponseWriter, r *http.Request) {
+// LoginWithOAuthProvider operation middleware
+func (siw *ServerInterfaceWrapper) LoginWithOAuthProvider(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", chi.URLParam(r, "provider"), &provider, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LoginWithGoogle(w, r)
+		siw.Handler.LoginWithOAuthProvider(w, r, provider)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -290,6 +1483,131 @@ func (siw *ServerInterfaceWrapper) RefreshAccessToken(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
+// RevokeToken operation middleware
+func (siw *ServerInterfaceWrapper) RevokeToken(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetSessions(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSessions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeOtherSessions operation middleware
+func (siw *ServerInterfaceWrapper) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeOtherSessions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeSession operation middleware
+func (siw *ServerInterfaceWrapper) RevokeSession(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "session_id" -------------
+	var sessionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "session_id", chi.URLParam(r, "session_id"), &sessionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "session_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeSession(w, r, sessionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// IssueLoginKey operation middleware
+func (siw *ServerInterfaceWrapper) IssueLoginKey(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ServiceApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.IssueLoginKey(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCurrentUser operation middleware
+func (siw *ServerInterfaceWrapper) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCurrentUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -403,21 +1721,93 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/.well-known/jwks.json", wrapper.GetJWKS)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/failed-access-logs", wrapper.AdminGetFailedAccessLogs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/identities/{provider}/{subject}", wrapper.AdminGetUserByIdentity)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/roles", wrapper.AdminGetRoles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/roles/{role_name}", wrapper.AdminDeleteRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/roles/{role_name}", wrapper.AdminPutRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/roles/{role_name}/permissions/{permission}", wrapper.AdminRevokePermission)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/users/{user_uuid}", wrapper.AdminGetUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/users/{user_uuid}/access-logs", wrapper.AdminGetUserAccessLogs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/users/{user_uuid}/identities/{provider}/{subject}", wrapper.AdminUnlinkIdentity)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/{user_uuid}/login-key", wrapper.AdminIssueLoginKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/users/{user_uuid}/roles/{role_name}", wrapper.AdminUnassignRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/users/{user_uuid}/roles/{role_name}", wrapper.AdminAssignRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/users/{user_uuid}/sessions", wrapper.AdminRevokeUserSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/users/{user_uuid}/sessions", wrapper.AdminGetUserSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/users/{user_uuid}/sessions/{session_id}", wrapper.AdminRevokeUserSession)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/users/{user_uuid}/status", wrapper.AdminUpdateUserStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/introspect", wrapper.IntrospectToken)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/auth/logout", wrapper.Logout)
 	})
 	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/auth/oauth/google/callback", wrapper.CallbackFromGoogle)
+		r.Get(options.BaseURL+"/auth/oauth/{provider}/callback", wrapper.CallbackFromOAuthProvider)
 	})
 	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/auth/oauth/google/link", wrapper.LinkWithGoogle)
+		r.Post(options.BaseURL+"/auth/oauth/{provider}/link", wrapper.LinkWithOAuthProvider)
 	})
 	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/auth/oauth/google/login", wrapper.LoginWithGoogle)
+		r.Post(options.BaseURL+"/auth/oauth/{provider}/login", wrapper.LoginWithOAuthProvider)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/auth/refresh", wrapper.RefreshAccessToken)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/revoke", wrapper.RevokeToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/sessions", wrapper.GetSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/sessions/revoke-others", wrapper.RevokeOtherSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/auth/sessions/{session_id}", wrapper.RevokeSession)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/service/login-key", wrapper.IssueLoginKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/me", wrapper.GetCurrentUser)
+	})
 
 	return r
 }
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/Siroshun09/go-httplib"
+	"github.com/Siroshun09/logs"
+	"github.com/gofrs/uuid/v5"
+	"github.com/okocraft/auth-service/internal/domain"
+	"github.com/okocraft/auth-service/internal/usecases"
+	"github.com/okocraft/authlib/user"
+)
+
+// saveFailedAccessLog records the failed attempt. userID is 0 if the attempt cannot be attributed to a user,
+// and loginID is uuid.Nil if the attempt is not tied to a login.
+// The error is only logged because the failure response should be sent regardless.
+func saveFailedAccessLog(ctx context.Context, accessLogUsecase usecases.AccessLogUsecase, userID user.ID, loginID uuid.UUID, action domain.AccessLogActionType, reason domain.AccessLogFailureReason) {
+	log := httplib.GetRequestLogFromContext(ctx)
+	params := domain.AccessLogParams{
+		Action:    action,
+		Reason:    reason,
+		LoginID:   loginID,
+		IP:        log.GetIP(),
+		UserAgent: domain.TruncateUserAgent(log.UserAgent),
+		CreatedAt: time.Now(),
+	}
+
+	var err error
+	if userID == 0 {
+		err = accessLogUsecase.SaveUnattributedAccessLog(ctx, params)
+	} else {
+		err = accessLogUsecase.SaveAccessLogByUserID(ctx, userID, params)
+	}
+	if err != nil {
+		logs.Error(ctx, err)
+	}
+}
@@ -0,0 +1,664 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/Siroshun09/go-httplib"
+	"github.com/Siroshun09/logs"
+	"github.com/Siroshun09/serrors"
+	"github.com/gofrs/uuid/v5"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/okocraft/auth-service/internal/domain"
+	"github.com/okocraft/auth-service/internal/handler/http/oapi"
+	"github.com/okocraft/auth-service/internal/usecases"
+	"github.com/okocraft/authlib/user"
+)
+
+const (
+	adminAccessLogsDefaultLimit = 20
+	adminAccessLogsMaxLimit     = 100
+)
+
+type adminHandler struct {
+	authUsecase      usecases.AuthUsecase
+	userUsecase      usecases.UserUsecase
+	roleUsecase      usecases.RoleUsecase
+	sessionUsecase   usecases.SessionUsecase
+	accessLogUsecase usecases.AccessLogUsecase
+}
+
+func newAdminHandler(authUsecase usecases.AuthUsecase, userUsecase usecases.UserUsecase, roleUsecase usecases.RoleUsecase, sessionUsecase usecases.SessionUsecase, accessLogUsecase usecases.AccessLogUsecase) adminHandler {
+	return adminHandler{
+		authUsecase:      authUsecase,
+		userUsecase:      userUsecase,
+		roleUsecase:      roleUsecase,
+		sessionUsecase:   sessionUsecase,
+		accessLogUsecase: accessLogUsecase,
+	}
+}
+
+func (h adminHandler) AdminGetUser(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID) {
+	ctx := r.Context()
+
+	userID, ok := h.getUserIDByUUID(ctx, w, userUuid)
+	if !ok {
+		return
+	}
+
+	h.renderAdminUser(ctx, w, userID)
+}
+
+func (h adminHandler) AdminGetUserByIdentity(w http.ResponseWriter, r *http.Request, provider string, subject string) {
+	ctx := r.Context()
+
+	userID, err := h.userUsecase.GetUserIDBySub(ctx, provider, subject)
+	if errors.Is(err, domain.UserNotFoundBySubError) {
+		httplib.RenderNotFound(ctx, w, err)
+		return
+	} else if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	h.renderAdminUser(ctx, w, userID)
+}
+
+func (h adminHandler) renderAdminUser(ctx context.Context, w http.ResponseWriter, userID user.ID) {
+	profile, err := h.userUsecase.GetProfile(ctx, userID)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	authz, err := h.roleUsecase.GetAuthorization(ctx, userID)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	body := oapi.AdminUserResponse{
+		Uuid:       openapi_types.UUID(profile.User.UUID),
+		CreatedAt:  profile.User.CreatedAt,
+		Status:     toOAPIUserStatus(profile.User.Status),
+		Identities: make([]oapi.UserIdentity, 0, len(profile.Identities)),
+		Roles:      authz.Roles,
+	}
+	for _, identity := range profile.Identities {
+		body.Identities = append(body.Identities, oapi.UserIdentity{
+			Provider: identity.Provider,
+			Subject:  identity.Subject,
+			LinkedAt: identity.CreatedAt,
+		})
+	}
+	if body.Roles == nil {
+		body.Roles = []string{}
+	}
+
+	res, err := httplib.JSONResponse(body)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	err = httplib.RenderOKWithBody(ctx, w, res)
+	if err != nil {
+		logs.Error(ctx, err)
+	}
+}
+
+func (h adminHandler) AdminGetUserAccessLogs(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID, params oapi.AdminGetUserAccessLogsParams) {
+	ctx := r.Context()
+
+	page, err := toAccessLogPageParams(params.Limit, params.Before)
+	if err != nil {
+		httplib.RenderBadRequest(ctx, w, err)
+		return
+	}
+
+	userID, ok := h.getUserIDByUUID(ctx, w, userUuid)
+	if !ok {
+		return
+	}
+
+	var result domain.AccessLogPage
+	if params.SessionId != nil {
+		result, err = h.accessLogUsecase.GetAccessLogsOfLogin(ctx, userID, uuid.UUID(*params.SessionId), page)
+	} else {
+		result, err = h.accessLogUsecase.GetAccessLogs(ctx, userID, page)
+	}
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	body := oapi.AccessLogListResponse{AccessLogs: make([]oapi.AccessLog, 0, len(result.AccessLogs))}
+	for _, accessLog := range result.AccessLogs {
+		body.AccessLogs = append(body.AccessLogs, toOAPIAccessLog(accessLog))
+	}
+	if result.NextBefore != 0 {
+		body.NextBefore = &result.NextBefore
+	}
+
+	res, err := httplib.JSONResponse(body)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	err = httplib.RenderOKWithBody(ctx, w, res)
+	if err != nil {
+		logs.Error(ctx, err)
+	}
+}
+
+func (h adminHandler) AdminGetFailedAccessLogs(w http.ResponseWriter, r *http.Request, params oapi.AdminGetFailedAccessLogsParams) {
+	ctx := r.Context()
+
+	page, err := toAccessLogPageParams(params.Limit, params.Before)
+	if err != nil {
+		httplib.RenderBadRequest(ctx, w, err)
+		return
+	}
+
+	var ip net.IP
+	if params.Ip != nil {
+		ip = net.ParseIP(*params.Ip)
+		if ip == nil {
+			httplib.RenderBadRequest(ctx, w, serrors.Errorf("invalid IP address: %s", *params.Ip))
+			return
+		}
+	}
+
+	result, err := h.accessLogUsecase.GetFailedAccessLogs(ctx, ip, page)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	body := oapi.FailedAccessLogListResponse{AccessLogs: make([]oapi.FailedAccessLog, 0, len(result.AccessLogs))}
+	for _, accessLog := range result.AccessLogs {
+		converted := toOAPIAccessLog(accessLog)
+
+		var userUUID *openapi_types.UUID
+		if accessLog.UserUUID != uuid.Nil {
+			id := openapi_types.UUID(accessLog.UserUUID)
+			userUUID = &id
+		}
+
+		body.AccessLogs = append(body.AccessLogs, oapi.FailedAccessLog{
+			Id:        converted.Id,
+			UserUuid:  userUUID,
+			Action:    converted.Action,
+			Reason:    converted.Reason,
+			SessionId: converted.SessionId,
+			Ip:        converted.Ip,
+			UserAgent: converted.UserAgent,
+			CreatedAt: converted.CreatedAt,
+		})
+	}
+	if result.NextBefore != 0 {
+		body.NextBefore = &result.NextBefore
+	}
+
+	res, err := httplib.JSONResponse(body)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	err = httplib.RenderOKWithBody(ctx, w, res)
+	if err != nil {
+		logs.Error(ctx, err)
+	}
+}
+
+func (h adminHandler) AdminGetUserSessions(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID) {
+	ctx := r.Context()
+
+	userID, ok := h.getUserIDByUUID(ctx, w, userUuid)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.sessionUsecase.GetSessions(ctx, userID)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	res, err := httplib.JSONResponse(newSessionListResponse(sessions, uuid.Nil))
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	err = httplib.RenderOKWithBody(ctx, w, res)
+	if err != nil {
+		logs.Error(ctx, err)
+	}
+}
+
+func (h adminHandler) AdminRevokeUserSessions(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID) {
+	ctx := r.Context()
+
+	userID, ok := h.getUserIDByUUID(ctx, w, userUuid)
+	if !ok {
+		return
+	}
+
+	revoked, err := h.sessionUsecase.RevokeAllSessions(ctx, userID)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	if err := saveRevocationAccessLogs(r, h.accessLogUsecase, userID, domain.AccessLogActionTypeRevokedByAdmin, revoked...); err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	httplib.RenderNoContent(ctx, w)
+}
+
+func (h adminHandler) AdminRevokeUserSession(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID, sessionId openapi_types.UUID) {
+	ctx := r.Context()
+
+	userID, ok := h.getUserIDByUUID(ctx, w, userUuid)
+	if !ok {
+		return
+	}
+
+	loginID := uuid.UUID(sessionId)
+	err := h.sessionUsecase.RevokeSession(ctx, userID, loginID)
+	if errors.Is(err, domain.SessionNotFoundError) {
+		httplib.RenderNotFound(ctx, w, err)
+		return
+	} else if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	if err := saveRevocationAccessLogs(r, h.accessLogUsecase, userID, domain.AccessLogActionTypeRevokedByAdmin, loginID); err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	httplib.RenderNoContent(ctx, w)
+}
+
+func (h adminHandler) AdminIssueLoginKey(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID) {
+	ctx := r.Context()
+
+	userID, ok := h.getUserIDByUUID(ctx, w, userUuid)
+	if !ok {
+		return
+	}
+
+	loginKey, expiresAt, err := h.authUsecase.CreateLoginKey(ctx, userID)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	res, err := httplib.JSONResponse(oapi.IssueLoginKeyResponse{
+		LoginKey:  loginKey.String(),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	err = httplib.RenderOKWithBody(ctx, w, res)
+	if err != nil {
+		logs.Error(ctx, err)
+	}
+}
+
+func (h adminHandler) AdminUnlinkIdentity(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID, provider string, subject string) {
+	ctx := r.Context()
+
+	userID, ok := h.getUserIDByUUID(ctx, w, userUuid)
+	if !ok {
+		return
+	}
+
+	err := h.userUsecase.UnlinkIdentity(ctx, userID, provider, subject)
+	switch {
+	case errors.Is(err, domain.IdentityNotFoundError):
+		httplib.RenderNotFound(ctx, w, err)
+		return
+	case errors.Is(err, domain.LastIdentityUnlinkError):
+		httplib.RenderConflict(ctx, w, err)
+		return
+	case err != nil:
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	httplib.RenderNoContent(ctx, w)
+}
+
+func (h adminHandler) AdminUpdateUserStatus(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID) {
+	ctx := r.Context()
+
+	req, err := httplib.DecodeJSONRequestBody[oapi.AdminUserStatus](r)
+	if err != nil {
+		httplib.RenderBadRequest(ctx, w, err)
+		return
+	}
+
+	status, err := toDomainUserStatus(req)
+	if err != nil {
+		httplib.RenderBadRequest(ctx, w, err)
+		return
+	}
+
+	userID, ok := h.getUserIDByUUID(ctx, w, userUuid)
+	if !ok {
+		return
+	}
+
+	revoked, err := h.userUsecase.UpdateStatus(ctx, userID, status)
+	if errors.Is(err, domain.InvalidUserStatusError) {
+		httplib.RenderBadRequest(ctx, w, err)
+		return
+	} else if errors.Is(err, domain.UserNotFoundError) {
+		httplib.RenderNotFound(ctx, w, err)
+		return
+	} else if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	if err := saveRevocationAccessLogs(r, h.accessLogUsecase, userID, domain.AccessLogActionTypeRevokedByAdmin, revoked...); err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	httplib.RenderNoContent(ctx, w)
+}
+
+func (h adminHandler) AdminAssignRole(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID, roleName string) {
+	ctx := r.Context()
+
+	userID, ok := h.getUserIDByUUID(ctx, w, userUuid)
+	if !ok {
+		return
+	}
+
+	err := h.roleUsecase.AssignRole(ctx, userID, roleName)
+	if errors.Is(err, domain.RoleNotFoundError) {
+		httplib.RenderNotFound(ctx, w, err)
+		return
+	} else if err != nil && !errors.Is(err, domain.RoleAlreadyAssignedError) {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	httplib.RenderNoContent(ctx, w)
+}
+
+func (h adminHandler) AdminUnassignRole(w http.ResponseWriter, r *http.Request, userUuid openapi_types.UUID, roleName string) {
+	ctx := r.Context()
+
+	userID, ok := h.getUserIDByUUID(ctx, w, userUuid)
+	if !ok {
+		return
+	}
+
+	err := h.roleUsecase.UnassignRole(ctx, userID, roleName)
+	if errors.Is(err, domain.RoleNotFoundError) || errors.Is(err, domain.RoleNotAssignedError) {
+		httplib.RenderNotFound(ctx, w, err)
+		return
+	} else if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	httplib.RenderNoContent(ctx, w)
+}
+
+func (h adminHandler) AdminGetRoles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	roles, err := h.roleUsecase.GetRoles(ctx)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	body := oapi.RoleListResponse{Roles: make([]oapi.Role, 0, len(roles))}
+	for _, role := range roles {
+		body.Roles = append(body.Roles, oapi.Role{
+			Name:        role.Name,
+			Permissions: role.Permissions,
+			CreatedAt:   role.CreatedAt,
+		})
+	}
+
+	res, err := httplib.JSONResponse(body)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	err = httplib.RenderOKWithBody(ctx, w, res)
+	if err != nil {
+		logs.Error(ctx, err)
+	}
+}
+
+func (h adminHandler) AdminPutRole(w http.ResponseWriter, r *http.Request, roleName string) {
+	ctx := r.Context()
+
+	req, err := httplib.DecodeJSONRequestBody[oapi.PutRoleRequest](r)
+	if err != nil {
+		httplib.RenderBadRequest(ctx, w, err)
+		return
+	}
+
+	err = h.roleUsecase.CreateRole(ctx, roleName, req.Permissions)
+	if errors.Is(err, domain.InvalidRoleNameError) || errors.Is(err, domain.InvalidPermissionError) {
+		httplib.RenderBadRequest(ctx, w, err)
+		return
+	} else if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	httplib.RenderNoContent(ctx, w)
+}
+
+func (h adminHandler) AdminDeleteRole(w http.ResponseWriter, r *http.Request, roleName string) {
+	ctx := r.Context()
+
+	err := h.roleUsecase.DeleteRole(ctx, roleName)
+	if errors.Is(err, domain.RoleNotFoundError) {
+		httplib.RenderNotFound(ctx, w, err)
+		return
+	} else if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	httplib.RenderNoContent(ctx, w)
+}
+
+func (h adminHandler) AdminRevokePermission(w http.ResponseWriter, r *http.Request, roleName string, permission string) {
+	ctx := r.Context()
+
+	err := h.roleUsecase.RevokePermission(ctx, roleName, permission)
+	if errors.Is(err, domain.RoleNotFoundError) || errors.Is(err, domain.PermissionNotFoundError) {
+		httplib.RenderNotFound(ctx, w, err)
+		return
+	} else if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	httplib.RenderNoContent(ctx, w)
+}
+
+// getUserIDByUUID renders 404 and returns false if the user does not exist.
+func (h adminHandler) getUserIDByUUID(ctx context.Context, w http.ResponseWriter, userUUID openapi_types.UUID) (user.ID, bool) {
+	userID, err := h.userUsecase.GetUserIDByUUID(ctx, uuid.UUID(userUUID))
+	if errors.Is(err, domain.UserNotFoundError) {
+		httplib.RenderNotFound(ctx, w, err)
+		return 0, false
+	} else if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return 0, false
+	}
+	return userID, true
+}
+
+func toOAPIUserStatus(status domain.UserStatus) oapi.AdminUserStatus {
+	ret := oapi.AdminUserStatus{}
+	switch status.Type {
+	case domain.UserStatusTypeSuspended:
+		ret.Type = oapi.UserStatusTypeSuspended
+		ret.SuspendedUntil = &status.SuspendedUntil
+	case domain.UserStatusTypeBanned:
+		ret.Type = oapi.UserStatusTypeBanned
+	default:
+		ret.Type = oapi.UserStatusTypeActive
+	}
+
+	if status.Reason != "" {
+		ret.Reason = &status.Reason
+	}
+
+	return ret
+}
+
+func toDomainUserStatus(status oapi.AdminUserStatus) (domain.UserStatus, error) {
+	ret := domain.UserStatus{}
+	switch status.Type {
+	case oapi.UserStatusTypeActive:
+		ret.Type = domain.UserStatusTypeActive
+	case oapi.UserStatusTypeSuspended:
+		if status.SuspendedUntil == nil {
+			return domain.UserStatus{}, serrors.New("suspended_until is required")
+		}
+		ret.Type = domain.UserStatusTypeSuspended
+		ret.SuspendedUntil = *status.SuspendedUntil
+	case oapi.UserStatusTypeBanned:
+		ret.Type = domain.UserStatusTypeBanned
+	default:
+		return domain.UserStatus{}, serrors.New("unknown status type: " + string(status.Type))
+	}
+
+	if status.Reason != nil {
+		ret.Reason = *status.Reason
+	}
+
+	return ret, nil
+}
+
+func toAccessLogPageParams(limit *int32, before *int64) (domain.AccessLogPageParams, error) {
+	page := domain.AccessLogPageParams{Limit: adminAccessLogsDefaultLimit}
+	if limit != nil {
+		page.Limit = *limit
+	}
+	if page.Limit <= 0 || adminAccessLogsMaxLimit < page.Limit {
+		return domain.AccessLogPageParams{}, serrors.Errorf("limit must be between 1 and %d", adminAccessLogsMaxLimit)
+	}
+
+	if before != nil {
+		if *before <= 0 {
+			return domain.AccessLogPageParams{}, serrors.New("before must be positive")
+		}
+		page.Before = *before
+	}
+
+	return page, nil
+}
+
+func toOAPIAccessLog(accessLog domain.AccessLog) oapi.AccessLog {
+	var ip string
+	if accessLog.IP != nil {
+		ip = accessLog.IP.String()
+	}
+
+	var sessionID *openapi_types.UUID
+	if accessLog.LoginID != uuid.Nil {
+		id := openapi_types.UUID(accessLog.LoginID)
+		sessionID = &id
+	}
+
+	var reason *oapi.AccessLogFailureReason
+	if accessLog.Reason != domain.AccessLogFailureReasonNone {
+		converted := toOAPIAccessLogFailureReason(accessLog.Reason)
+		reason = &converted
+	}
+
+	return oapi.AccessLog{
+		Id:        accessLog.ID,
+		Action:    toOAPIAccessLogAction(accessLog.Action),
+		Reason:    reason,
+		SessionId: sessionID,
+		Ip:        ip,
+		UserAgent: accessLog.UserAgent,
+		CreatedAt: accessLog.CreatedAt,
+	}
+}
+
+func toOAPIAccessLogAction(action domain.AccessLogActionType) oapi.AccessLogAction {
+	switch action {
+	case domain.AccessLogActionTypeLogin:
+		return oapi.AccessLogActionLogin
+	case domain.AccessLogActionTypeLogout:
+		return oapi.AccessLogActionLogout
+	case domain.AccessLogActionTypeFirstLogin:
+		return oapi.AccessLogActionFirstLogin
+	case domain.AccessLogActionTypeRefreshToken:
+		return oapi.AccessLogActionRefreshToken
+	case domain.AccessLogActionTypeRefreshTokenReuseDetected:
+		return oapi.AccessLogActionRefreshTokenReuseDetected
+	case domain.AccessLogActionTypeSessionRevoked:
+		return oapi.AccessLogActionSessionRevoked
+	case domain.AccessLogActionTypeTokenRevoked:
+		return oapi.AccessLogActionTokenRevoked
+	case domain.AccessLogActionTypeRevokedByAdmin:
+		return oapi.AccessLogActionRevokedByAdmin
+	case domain.AccessLogActionTypeLoginDenied:
+		return oapi.AccessLogActionLoginDenied
+	case domain.AccessLogActionTypeRefreshDenied:
+		return oapi.AccessLogActionRefreshDenied
+	case domain.AccessLogActionTypeLoginFailed:
+		return oapi.AccessLogActionLoginFailed
+	case domain.AccessLogActionTypeRefreshFailed:
+		return oapi.AccessLogActionRefreshFailed
+	default:
+		return oapi.AccessLogAction("unknown")
+	}
+}
+
+func toOAPIAccessLogFailureReason(reason domain.AccessLogFailureReason) oapi.AccessLogFailureReason {
+	switch reason {
+	case domain.AccessLogFailureReasonInvalidState:
+		return oapi.AccessLogFailureReasonInvalidState
+	case domain.AccessLogFailureReasonProviderError:
+		return oapi.AccessLogFailureReasonProviderError
+	case domain.AccessLogFailureReasonUnknownSubject:
+		return oapi.AccessLogFailureReasonUnknownSubject
+	case domain.AccessLogFailureReasonLoginKeyNotFound:
+		return oapi.AccessLogFailureReasonLoginKeyNotFound
+	case domain.AccessLogFailureReasonLoginKeyExpired:
+		return oapi.AccessLogFailureReasonLoginKeyExpired
+	case domain.AccessLogFailureReasonSubjectAlreadyLinked:
+		return oapi.AccessLogFailureReasonSubjectAlreadyLinked
+	case domain.AccessLogFailureReasonCSRFMismatch:
+		return oapi.AccessLogFailureReasonCsrfMismatch
+	case domain.AccessLogFailureReasonInvalidRefreshToken:
+		return oapi.AccessLogFailureReasonInvalidRefreshToken
+	case domain.AccessLogFailureReasonAccountSuspended:
+		return oapi.AccessLogFailureReasonAccountSuspended
+	default:
+		return oapi.AccessLogFailureReason("unknown")
+	}
+}
@@ -3,10 +3,14 @@ package server
 import (
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Siroshun09/go-httplib"
 	"github.com/Siroshun09/logs"
+	"github.com/Siroshun09/serrors"
+	"github.com/gofrs/uuid/v5"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 	"github.com/okocraft/auth-service/internal/domain"
 	"github.com/okocraft/auth-service/internal/handler/http/oapi"
 	"github.com/okocraft/auth-service/internal/usecases"
@@ -84,18 +88,22 @@ func (h authHandler) RefreshAccessToken(w http.ResponseWriter, r *http.Request,
 	ctx := r.Context()
 
 	if err := checkCSRFToken(r, params.XCSRFToken); err != nil {
+		saveFailedAccessLog(ctx, h.accessLogUsecase, 0, uuid.Nil, domain.AccessLogActionTypeRefreshFailed, domain.AccessLogFailureReasonCSRFMismatch)
 		httplib.RenderUnauthorized(ctx, w, err)
 		return
 	}
 
 	refreshTokenClaims, err := h.authUsecase.VerifyRefreshToken(ctx, params.RefreshToken)
 	if err != nil {
+		saveFailedAccessLog(ctx, h.accessLogUsecase, 0, uuid.Nil, domain.AccessLogActionTypeRefreshFailed, domain.AccessLogFailureReasonInvalidRefreshToken)
 		httplib.RenderUnauthorized(ctx, w, err)
 		return
 	}
 
 	userID, refreshTokenID, err := h.authUsecase.GetUserIDAndRefreshTokenIDFromJTI(ctx, refreshTokenClaims.JTI)
 	if errors.Is(err, domain.RefreshTokenIDByJTINotFoundError) {
+		// the token is signed by us, so the login is known even though its refresh token is already deleted
+		saveFailedAccessLog(ctx, h.accessLogUsecase, 0, refreshTokenClaims.LoginID, domain.AccessLogActionTypeRefreshFailed, domain.AccessLogFailureReasonInvalidRefreshToken)
 		httplib.RenderUnauthorized(ctx, w, err)
 		return
 	} else if err != nil {
@@ -109,7 +117,44 @@ func (h authHandler) RefreshAccessToken(w http.ResponseWriter, r *http.Request,
 		LoginID:        refreshTokenClaims.LoginID,
 		MaxExpiresAt:   refreshTokenClaims.ExpiresAt,
 	})
-	if err != nil {
+	if errors.Is(err, domain.RefreshTokenAlreadyConsumedError) {
+		unsetRefreshTokenCookie(w)
+
+		log := httplib.GetRequestLogFromContext(ctx)
+		saveErr := h.accessLogUsecase.SaveAccessLogByUserID(ctx, userID, domain.AccessLogParams{
+			Action:    domain.AccessLogActionTypeRefreshTokenReuseDetected,
+			LoginID:   refreshTokenClaims.LoginID,
+			IP:        log.GetIP(),
+			UserAgent: domain.TruncateUserAgent(log.UserAgent),
+			CreatedAt: time.Now(),
+		})
+		if saveErr != nil {
+			httplib.RenderInternalServerError(ctx, w, errors.Join(err, saveErr))
+			return
+		}
+
+		httplib.RenderUnauthorized(ctx, w, err)
+		return
+	} else if errors.Is(err, domain.AccountSuspendedError) {
+		unsetRefreshTokenCookie(w)
+
+		log := httplib.GetRequestLogFromContext(ctx)
+		saveErr := h.accessLogUsecase.SaveAccessLogByUserID(ctx, userID, domain.AccessLogParams{
+			Action:    domain.AccessLogActionTypeRefreshDenied,
+			Reason:    domain.AccessLogFailureReasonAccountSuspended,
+			LoginID:   refreshTokenClaims.LoginID,
+			IP:        log.GetIP(),
+			UserAgent: domain.TruncateUserAgent(log.UserAgent),
+			CreatedAt: time.Now(),
+		})
+		if saveErr != nil {
+			httplib.RenderInternalServerError(ctx, w, errors.Join(err, saveErr))
+			return
+		}
+
+		httplib.RenderUnauthorized(ctx, w, err)
+		return
+	} else if err != nil {
 		httplib.RenderInternalServerError(ctx, w, err)
 		return
 	}
@@ -148,3 +193,101 @@ func (h authHandler) RefreshAccessToken(w http.ResponseWriter, r *http.Request,
 		logs.Error(ctx, err)
 	}
 }
+
+func (h authHandler) IntrospectToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		httplib.RenderBadRequest(ctx, w, err)
+		return
+	}
+
+	token := r.PostForm.Get("token")
+	if token == "" {
+		httplib.RenderBadRequest(ctx, w, serrors.New("token is required"))
+		return
+	}
+
+	introspection, err := h.authUsecase.IntrospectAccessToken(ctx, token)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	body := oapi.IntrospectionResponse{Active: introspection.Active}
+	if introspection.Active {
+		sub := openapi_types.UUID(introspection.UserUUID)
+		jti := introspection.JTI.String()
+		iat := introspection.IssuedAt.Unix()
+		exp := introspection.ExpiresAt.Unix()
+		tokenType := "access_token"
+		scope := strings.Join(introspection.Authorization.Permissions, " ")
+		roles := introspection.Authorization.Roles
+
+		body.Sub = &sub
+		body.Jti = &jti
+		body.Iat = &iat
+		body.Exp = &exp
+		body.TokenType = &tokenType
+		body.Scope = &scope
+		body.Roles = &roles
+	}
+
+	res, err := httplib.JSONResponse(body)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	err = httplib.RenderOKWithBody(ctx, w, res)
+	if err != nil {
+		logs.Error(ctx, err)
+	}
+}
+
+func (h authHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		httplib.RenderBadRequest(ctx, w, err)
+		return
+	}
+
+	token := r.PostForm.Get("token")
+	if token == "" {
+		httplib.RenderBadRequest(ctx, w, serrors.New("token is required"))
+		return
+	}
+
+	userID, loginID, err := h.authUsecase.FindLoginByToken(ctx, token, r.PostForm.Get("token_type_hint") == "refresh_token")
+	if errors.Is(err, domain.LoginNotFoundByTokenError) {
+		httplib.RenderOK(ctx, w) // RFC 7009: invalid tokens do not cause an error response
+		return
+	} else if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	err = h.authUsecase.InvalidateTokensByLoginID(ctx, loginID)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	log := httplib.GetRequestLogFromContext(ctx)
+	err = h.accessLogUsecase.SaveAccessLogByUserID(ctx, userID, domain.AccessLogParams{
+		Action:    domain.AccessLogActionTypeTokenRevoked,
+		LoginID:   loginID,
+		IP:        log.GetIP(),
+		UserAgent: domain.TruncateUserAgent(log.UserAgent),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	httplib.RenderOK(ctx, w)
+}
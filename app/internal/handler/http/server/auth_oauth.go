@@ -0,0 +1,320 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Siroshun09/go-httplib"
+	"github.com/Siroshun09/logs"
+	"github.com/Siroshun09/serrors"
+	"github.com/gofrs/uuid/v5"
+	"github.com/okocraft/auth-service/internal/config"
+	"github.com/okocraft/auth-service/internal/domain"
+	"github.com/okocraft/auth-service/internal/handler/http/oapi"
+	"github.com/okocraft/auth-service/internal/oauth"
+	"github.com/okocraft/auth-service/internal/usecases"
+	"github.com/okocraft/authlib/jwtclaims"
+	"github.com/okocraft/authlib/user"
+	"golang.org/x/oauth2"
+)
+
+type oauthHandler struct {
+	resultPageURL     string
+	redirectAllowlist domain.RedirectAllowlist
+	providers         oauth.Registry
+	accessLogUsecase  usecases.AccessLogUsecase
+	authUsecase       usecases.AuthUsecase
+	userUsecase       usecases.UserUsecase
+}
+
+func newOAuthHandler(c config.OAuthConfig, redirectAllowlist domain.RedirectAllowlist, providers oauth.Registry, accessLogUsecase usecases.AccessLogUsecase, authUsecase usecases.AuthUsecase, userUsecase usecases.UserUsecase) oauthHandler {
+	return oauthHandler{
+		resultPageURL:     c.ResultPageURL,
+		redirectAllowlist: redirectAllowlist,
+		providers:         providers,
+		accessLogUsecase:  accessLogUsecase,
+		authUsecase:       authUsecase,
+		userUsecase:       userUsecase,
+	}
+}
+
+func (h oauthHandler) LinkWithOAuthProvider(w http.ResponseWriter, r *http.Request, providerName string) {
+	ctx := r.Context()
+
+	provider, ok := h.providers.Get(providerName)
+	if !ok {
+		httplib.RenderNotFound(ctx, w, serrors.New("unknown oauth provider: "+providerName))
+		return
+	}
+
+	req, err := httplib.DecodeJSONRequestBody[oapi.OAuthLinkRequest](r)
+	if err != nil {
+		httplib.RenderBadRequest(ctx, w, err)
+		return
+	}
+
+	parsedLoginKey, err := domain.ParseLoginKey(req.LoginKey)
+	if err != nil {
+		saveFailedAccessLog(ctx, h.accessLogUsecase, 0, uuid.Nil, domain.AccessLogActionTypeLoginFailed, domain.AccessLogFailureReasonLoginKeyNotFound)
+		httplib.RenderBadRequest(ctx, w, err)
+		return
+	}
+
+	userID, err := h.userUsecase.VerifyLoginKey(ctx, parsedLoginKey)
+	switch {
+	case errors.Is(err, domain.UserNotFoundByLoginKeyError):
+		saveFailedAccessLog(ctx, h.accessLogUsecase, 0, uuid.Nil, domain.AccessLogActionTypeLoginFailed, domain.AccessLogFailureReasonLoginKeyNotFound)
+		h.renderOAuthLoginResponseWithResult(ctx, w, oapi.OAuthLoginResultLoginKeyNotFound)
+		return
+	case errors.Is(err, domain.LoginKeyExpiredError):
+		saveFailedAccessLog(ctx, h.accessLogUsecase, userID, uuid.Nil, domain.AccessLogActionTypeLoginFailed, domain.AccessLogFailureReasonLoginKeyExpired)
+		h.renderOAuthLoginResponseWithResult(ctx, w, oapi.OAuthLoginResultLoginKeyExpired)
+		return
+	case errors.Is(err, domain.AccountSuspendedError):
+		saveFailedAccessLog(ctx, h.accessLogUsecase, userID, uuid.Nil, domain.AccessLogActionTypeLoginDenied, domain.AccessLogFailureReasonAccountSuspended)
+		h.renderOAuthLoginResponseWithResult(ctx, w, oapi.OAuthLoginResultAccountSuspended)
+		return
+	case err != nil:
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	verifier := oauth2.GenerateVerifier()
+	state, err := h.authUsecase.CreateStateJWTWithLoginKey(ctx, parsedLoginKey, verifier)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	h.renderOAuthLoginResponse(ctx, w, provider, state, verifier)
+}
+
+func (h oauthHandler) LoginWithOAuthProvider(w http.ResponseWriter, r *http.Request, providerName string) {
+	ctx := r.Context()
+
+	provider, ok := h.providers.Get(providerName)
+	if !ok {
+		httplib.RenderNotFound(ctx, w, serrors.New("unknown oauth provider: "+providerName))
+		return
+	}
+
+	req, err := httplib.DecodeJSONRequestBody[oapi.OAuthLoginRequest](r)
+	if err != nil {
+		httplib.RenderBadRequest(ctx, w, err)
+		return
+	}
+
+	currentURL, err := h.redirectAllowlist.NormalizeRedirectURL(req.CurrentUrl)
+	if err != nil {
+		httplib.RenderBadRequest(ctx, w, err)
+		return
+	}
+
+	verifier := oauth2.GenerateVerifier()
+
+	state, err := h.authUsecase.CreateStateJWT(ctx, currentURL, verifier)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	h.renderOAuthLoginResponse(ctx, w, provider, state, verifier)
+}
+
+func (h oauthHandler) renderOAuthLoginResponse(ctx context.Context, w http.ResponseWriter, provider oauth.Provider, state string, verifier string) {
+	redirectURL, err := provider.AuthCodeURL(ctx, state, verifier)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	h.renderOAuthLoginResponseWithURL(ctx, w, redirectURL)
+}
+
+// renderOAuthLoginResponseWithResult sends the client to the result page directly instead of the provider's login page.
+func (h oauthHandler) renderOAuthLoginResponseWithResult(ctx context.Context, w http.ResponseWriter, result oapi.OAuthLoginResult) {
+	h.renderOAuthLoginResponseWithURL(ctx, w, h.createResultPageURL(result, ""))
+}
+
+func (h oauthHandler) renderOAuthLoginResponseWithURL(ctx context.Context, w http.ResponseWriter, redirectURL string) {
+	res, err := httplib.JSONResponse(oapi.OAuthLoginResponse{RedirectUrl: redirectURL})
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	err = httplib.RenderOKWithBody(ctx, w, res)
+	if err != nil {
+		logs.Error(ctx, err)
+	}
+}
+
+func (h oauthHandler) CallbackFromOAuthProvider(w http.ResponseWriter, r *http.Request, providerName string) {
+	ctx := r.Context()
+
+	provider, ok := h.providers.Get(providerName)
+	if !ok {
+		h.redirectToResultPage(ctx, w, r, oapi.OAuthLoginResultNotEnabled)
+		return
+	}
+
+	state := r.URL.Query().Get("state")
+
+	claimType, claims, err := h.authUsecase.VerifyStateJWT(ctx, state)
+	if err != nil {
+		h.redirectToFailureResultPage(ctx, w, r, domain.AccessLogFailureReasonInvalidState, oapi.OAuthLoginResultInvalidToken)
+		return
+	}
+
+	var encryptedVerifier string
+	var callbackHandleFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, sub string)
+	switch claimType {
+	case jwtclaims.LoginStateClaimTypeUnknown:
+		h.redirectToFailureResultPage(ctx, w, r, domain.AccessLogFailureReasonInvalidState, oapi.OAuthLoginResultInvalidToken)
+		return
+	case jwtclaims.LoginStateClaimTypeLogin:
+		loginStateClaims, err := jwtclaims.ReadLoginStateClaimsFrom(claims)
+		if err != nil {
+			h.redirectToFailureResultPage(ctx, w, r, domain.AccessLogFailureReasonInvalidState, oapi.OAuthLoginResultInvalidToken)
+			return
+		}
+
+		encryptedVerifier = loginStateClaims.EncryptedCodeVerifier
+		callbackHandleFunc = func(ctx context.Context, w http.ResponseWriter, r *http.Request, sub string) {
+			h.handleLoginCallback(w, r, provider.Name(), sub, loginStateClaims.CurrentPageURL)
+		}
+	case jwtclaims.LoginStateClaimTypeFirstLogin:
+		firstLoginStateClaims, err := jwtclaims.ReadFirstLoginStateClaimsFrom(claims)
+		if err != nil {
+			h.redirectToFailureResultPage(ctx, w, r, domain.AccessLogFailureReasonInvalidState, oapi.OAuthLoginResultInvalidToken)
+			return
+		}
+
+		encryptedVerifier = firstLoginStateClaims.EncryptedCodeVerifier
+		callbackHandleFunc = func(ctx context.Context, w http.ResponseWriter, r *http.Request, sub string) {
+			h.handleFirstLoginCallback(ctx, w, r, provider.Name(), sub, domain.LoginKey(firstLoginStateClaims.LoginKey))
+		}
+	default:
+		logs.Errorf(ctx, "unknown claim type: %v", claimType)
+		h.redirectToResultPage(ctx, w, r, oapi.OAuthLoginResultInternalError)
+		return
+	}
+
+	verifier, err := h.authUsecase.DecryptCodeVerifier(ctx, encryptedVerifier)
+	if err != nil {
+		h.redirectToFailureResultPage(ctx, w, r, domain.AccessLogFailureReasonInvalidState, oapi.OAuthLoginResultInvalidToken)
+		return
+	}
+
+	code := r.URL.Query().Get("code")
+	sub, err := provider.Exchange(ctx, code, verifier)
+	if err != nil {
+		logs.Warn(ctx, err)
+		h.redirectToFailureResultPage(ctx, w, r, domain.AccessLogFailureReasonProviderError, oapi.OAuthLoginResultInvalidToken)
+		return
+	}
+
+	callbackHandleFunc(ctx, w, r, sub)
+}
+
+func (h oauthHandler) handleFirstLoginCallback(ctx context.Context, w http.ResponseWriter, r *http.Request, providerName string, sub string, loginKey domain.LoginKey) {
+	usr, err := h.userUsecase.SaveSubByLoginKey(ctx, loginKey, providerName, sub)
+	switch {
+	case errors.Is(err, domain.UserNotFoundByLoginKeyError):
+		// usr is not 0 if the login key is consumed by another callback at the same time
+		saveFailedAccessLog(ctx, h.accessLogUsecase, usr, uuid.Nil, domain.AccessLogActionTypeLoginFailed, domain.AccessLogFailureReasonLoginKeyNotFound)
+		h.redirectToResultPage(ctx, w, r, oapi.OAuthLoginResultLoginKeyNotFound)
+		return
+	case errors.Is(err, domain.LoginKeyExpiredError):
+		saveFailedAccessLog(ctx, h.accessLogUsecase, usr, uuid.Nil, domain.AccessLogActionTypeLoginFailed, domain.AccessLogFailureReasonLoginKeyExpired)
+		h.redirectToResultPage(ctx, w, r, oapi.OAuthLoginResultLoginKeyExpired)
+		return
+	case errors.Is(err, domain.SubAlreadyLinkedError):
+		saveFailedAccessLog(ctx, h.accessLogUsecase, usr, uuid.Nil, domain.AccessLogActionTypeLoginFailed, domain.AccessLogFailureReasonSubjectAlreadyLinked)
+		h.redirectToResultPage(ctx, w, r, oapi.OAuthLoginResultAlreadyLinked)
+		return
+	case errors.Is(err, domain.AccountSuspendedError):
+		saveFailedAccessLog(ctx, h.accessLogUsecase, usr, uuid.Nil, domain.AccessLogActionTypeLoginDenied, domain.AccessLogFailureReasonAccountSuspended)
+		h.redirectToResultPage(ctx, w, r, oapi.OAuthLoginResultAccountSuspended)
+		return
+	case err != nil:
+		logs.Error(ctx, err)
+		h.redirectToResultPage(ctx, w, r, oapi.OAuthLoginResultInternalError)
+		return
+	}
+
+	h.sendTokens(ctx, w, r, usr, "", domain.AccessLogActionTypeFirstLogin)
+}
+
+func (h oauthHandler) handleLoginCallback(w http.ResponseWriter, r *http.Request, providerName string, sub string, redirectTo string) {
+	ctx := r.Context()
+	usr, err := h.userUsecase.GetUserIDBySub(ctx, providerName, sub)
+	if errors.Is(err, domain.UserNotFoundBySubError) {
+		h.redirectToFailureResultPage(ctx, w, r, domain.AccessLogFailureReasonUnknownSubject, oapi.OAuthLoginResultUserNotFound)
+		return
+	} else if err != nil {
+		logs.Error(ctx, err)
+		h.redirectToResultPage(ctx, w, r, oapi.OAuthLoginResultInternalError)
+		return
+	}
+
+	h.sendTokens(ctx, w, r, usr, redirectTo, domain.AccessLogActionTypeLogin)
+}
+
+func (h oauthHandler) sendTokens(ctx context.Context, w http.ResponseWriter, r *http.Request, userID user.ID, redirectTo string, action domain.AccessLogActionType) {
+	loginID, refreshToken, expiresAt, err := h.authUsecase.CreateRefreshToken(ctx, userID)
+	if errors.Is(err, domain.AccountSuspendedError) {
+		saveFailedAccessLog(ctx, h.accessLogUsecase, userID, uuid.Nil, domain.AccessLogActionTypeLoginDenied, domain.AccessLogFailureReasonAccountSuspended)
+		h.redirectToResultPage(ctx, w, r, oapi.OAuthLoginResultAccountSuspended)
+		return
+	} else if err != nil {
+		logs.Error(ctx, err)
+		h.redirectToResultPage(ctx, w, r, oapi.OAuthLoginResultInternalError)
+		return
+	}
+
+	log := httplib.GetRequestLogFromContext(ctx)
+	err = h.accessLogUsecase.SaveAccessLogByUserID(ctx, userID, domain.AccessLogParams{
+		Action:    action,
+		LoginID:   loginID,
+		IP:        log.GetIP(),
+		UserAgent: domain.TruncateUserAgent(log.UserAgent),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		logs.Error(ctx, err)
+		h.redirectToResultPage(ctx, w, r, oapi.OAuthLoginResultInternalError)
+		return
+	}
+
+	csrfToken, err := generateCSRFToken()
+	if err != nil {
+		logs.Error(ctx, err)
+		h.redirectToResultPage(ctx, w, r, oapi.OAuthLoginResultInternalError)
+		return
+	}
+
+	setRefreshTokenCookie(w, refreshToken, csrfToken, expiresAt)
+	httplib.RenderRedirect(ctx, w, r, h.createResultPageURL(oapi.OAuthLoginResultSuccess, redirectTo))
+}
+
+func (h oauthHandler) redirectToResultPage(ctx context.Context, w http.ResponseWriter, r *http.Request, result oapi.OAuthLoginResult) {
+	httplib.RenderRedirect(ctx, w, r, h.createResultPageURL(result, ""))
+}
+
+// redirectToFailureResultPage records the failed login attempt that cannot be attributed to a user and sends the client to the result page.
+func (h oauthHandler) redirectToFailureResultPage(ctx context.Context, w http.ResponseWriter, r *http.Request, reason domain.AccessLogFailureReason, result oapi.OAuthLoginResult) {
+	saveFailedAccessLog(ctx, h.accessLogUsecase, 0, uuid.Nil, domain.AccessLogActionTypeLoginFailed, reason)
+	h.redirectToResultPage(ctx, w, r, result)
+}
+
+func (h oauthHandler) createResultPageURL(result oapi.OAuthLoginResult, redirectTo string) string {
+	query := url.Values{"type": {string(result)}}
+	if redirectTo != "" {
+		query.Set("redirectTo", redirectTo)
+	}
+	return h.resultPageURL + "?" + query.Encode()
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/okocraft/auth-service/internal/handler/http/oapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthHandler_createResultPageURL(t *testing.T) {
+	h := oauthHandler{resultPageURL: "https://example.com/result"}
+
+	tests := []struct {
+		name           string
+		result         oapi.OAuthLoginResult
+		redirectTo     string
+		wantRedirectTo []string
+	}{
+		{
+			name:   "without redirect",
+			result: oapi.OAuthLoginResultInvalidToken,
+		},
+		{
+			name:           "with redirect",
+			result:         oapi.OAuthLoginResultSuccess,
+			redirectTo:     "https://example.com/mypage",
+			wantRedirectTo: []string{"https://example.com/mypage"},
+		},
+		{
+			name:           "redirect with query cannot add another redirectTo",
+			result:         oapi.OAuthLoginResultSuccess,
+			redirectTo:     "https://example.com/p?a=1&redirectTo=https://evil.example.com",
+			wantRedirectTo: []string{"https://example.com/p?a=1&redirectTo=https://evil.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(h.createResultPageURL(tt.result, tt.redirectTo))
+			require.NoError(t, err)
+
+			query := u.Query()
+			assert.Equal(t, []string{string(tt.result)}, query["type"])
+			assert.Equal(t, tt.wantRedirectTo, query["redirectTo"])
+		})
+	}
+}
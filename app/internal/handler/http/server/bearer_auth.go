@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Siroshun09/go-httplib"
+	"github.com/Siroshun09/serrors"
+	"github.com/okocraft/auth-service/internal/domain"
+	"github.com/okocraft/auth-service/internal/handler/http/oapi"
+	"github.com/okocraft/auth-service/internal/usecases"
+)
+
+type authenticatedUserKey struct{}
+
+func withAuthenticatedUser(ctx context.Context, owner domain.AccessTokenOwner) context.Context {
+	return context.WithValue(ctx, authenticatedUserKey{}, owner)
+}
+
+// getAuthenticatedUser returns the user authenticated by the bearer auth middleware.
+func getAuthenticatedUser(ctx context.Context) (domain.AccessTokenOwner, bool) {
+	owner, ok := ctx.Value(authenticatedUserKey{}).(domain.AccessTokenOwner)
+	return owner, ok
+}
+
+// newBearerAuthMiddleware checks the access token in the Authorization header for the operations that require oapi.BearerAuthScopes.
+func (f HTTPServerFactory) newBearerAuthMiddleware(authUsecase usecases.AuthUsecase) oapi.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			if ctx.Value(oapi.BearerAuthScopes) == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+			if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+				w.Header().Set("WWW-Authenticate", `Bearer`)
+				httplib.RenderUnauthorized(ctx, w, serrors.New("bearer token not found"))
+				return
+			}
+
+			owner, err := authUsecase.AuthenticateAccessToken(ctx, token)
+			if domain.IsUnauthorizedError(err) {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				httplib.RenderUnauthorized(ctx, w, err)
+				return
+			} else if err != nil {
+				httplib.RenderInternalServerError(ctx, w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(withAuthenticatedUser(ctx, owner)))
+		})
+	}
+}
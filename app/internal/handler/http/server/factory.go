@@ -17,7 +17,9 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 	"github.com/okocraft/auth-service/internal/config"
+	"github.com/okocraft/auth-service/internal/domain"
 	"github.com/okocraft/auth-service/internal/handler/http/oapi"
+	"github.com/okocraft/auth-service/internal/oauth"
 	"github.com/okocraft/auth-service/internal/repositories/database"
 	"github.com/okocraft/auth-service/internal/usecases"
 )
@@ -44,6 +46,9 @@ func NewHTTPServerFactory(cfg config.HTTPServerConfig, logger *slog.Logger, data
 }
 
 func (f HTTPServerFactory) NewHTTPServer() runner.HTTPServerRunner {
+	usecaseFactory := usecases.NewUsecaseFactory(f.cfg.AuthConfig, f.database)
+	rateLimitUsecase := usecaseFactory.NewRateLimitUsecase(f.cfg.RateLimitConfig)
+
 	r := chi.NewRouter()
 
 	r.Use(f.newRecoverer)
@@ -69,8 +74,18 @@ func (f HTTPServerFactory) NewHTTPServer() runner.HTTPServerRunner {
 
 	return runner.NewHTTPServerRunner(
 		&http.Server{
-			Addr:    ":" + f.cfg.Port,
-			Handler: oapi.HandlerFromMux(f.newAPIHandler(), r),
+			Addr: ":" + f.cfg.Port,
+			Handler: oapi.HandlerWithOptions(f.newAPIHandler(usecaseFactory), oapi.ChiServerOptions{
+				BaseRouter: r,
+				// the last middleware is the outermost, so the per-user rate limit runs after the bearer auth
+				Middlewares: []oapi.MiddlewareFunc{
+					f.newUserRateLimitMiddleware(rateLimitUsecase),
+					f.newServiceAPIKeyAuthMiddleware,
+					f.newAdminAPIKeyAuthMiddleware,
+					f.newBearerAuthMiddleware(usecaseFactory.NewAuthUsecase()),
+					f.newRateLimitMiddleware(rateLimitUsecase),
+				},
+			}),
 		},
 		func(ctx context.Context, err error) {
 			logs.Error(ctx, err)
@@ -132,18 +147,29 @@ func (f HTTPServerFactory) newLoggerMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-func (f HTTPServerFactory) newAPIHandler() oapi.ServerInterface {
-	usecaseFactory := usecases.NewUsecaseFactory(f.cfg.AuthConfig, f.database)
+func (f HTTPServerFactory) newAPIHandler(usecaseFactory usecases.UsecaseFactory) oapi.ServerInterface {
 	accessLogUsecase := usecaseFactory.NewAccessLogUsecase()
 	authUsecase := usecaseFactory.NewAuthUsecase()
 	userUsecase := usecaseFactory.NewUserUsecase()
+	sessionUsecase := usecaseFactory.NewSessionUsecase()
+	roleUsecase := usecaseFactory.NewRoleUsecase()
 	return &apiHandler{
-		authHandler:       newAuthHandler(authUsecase, accessLogUsecase),
-		googleAuthHandler: newGoogleAuthHandler(f.cfg.GoogleAuthConfig, accessLogUsecase, authUsecase, userUsecase),
+		adminHandler:     newAdminHandler(authUsecase, userUsecase, roleUsecase, sessionUsecase, accessLogUsecase),
+		authHandler:      newAuthHandler(authUsecase, accessLogUsecase),
+		oauthHandler:     newOAuthHandler(f.cfg.OAuthConfig, domain.NewRedirectAllowlist(f.cfg.AllowedRedirectURLs), oauth.NewRegistry(f.cfg.OAuthConfig), accessLogUsecase, authUsecase, userUsecase),
+		serviceHandler:   newServiceHandler(authUsecase, userUsecase),
+		sessionHandler:   newSessionHandler(sessionUsecase, accessLogUsecase),
+		userHandler:      newUserHandler(userUsecase),
+		wellKnownHandler: newWellKnownHandler(f.cfg.AuthConfig.AccessTokenSigner),
 	}
 }
 
 type apiHandler struct {
+	adminHandler
 	authHandler
-	googleAuthHandler
+	oauthHandler
+	serviceHandler
+	sessionHandler
+	userHandler
+	wellKnownHandler
 }
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Siroshun09/go-httplib"
+	"github.com/Siroshun09/serrors"
+	"github.com/go-chi/chi/v5"
+	"github.com/okocraft/auth-service/internal/domain"
+	"github.com/okocraft/auth-service/internal/handler/http/oapi"
+	"github.com/okocraft/auth-service/internal/usecases"
+)
+
+// rateLimitMaxPeekedBodySize is the maximum size of the request body read to find the login key.
+const rateLimitMaxPeekedBodySize = 4096
+
+type rateLimitRule struct {
+	name  string
+	limit domain.RateLimit
+	// key returns the key of the bucket for the request, or false if the rule does not apply to the request.
+	key func(r *http.Request) (string, bool)
+}
+
+// newRateLimitMiddleware limits the requests to the login and refresh endpoints per IP address and per login key.
+func (f HTTPServerFactory) newRateLimitMiddleware(rateLimitUsecase usecases.RateLimitUsecase) oapi.MiddlewareFunc {
+	conf := f.cfg.RateLimitConfig
+	if !conf.Enabled {
+		return passThroughMiddleware
+	}
+
+	loginRoutes := []string{
+		"/auth/oauth/{provider}/login",
+		"/auth/oauth/{provider}/link",
+		"/auth/oauth/{provider}/callback",
+	}
+
+	return newRateLimitMiddleware(rateLimitUsecase,
+		rateLimitRule{
+			name:  "login",
+			limit: domain.RateLimit(conf.Login),
+			key:   ipKeyForRoutes(loginRoutes...),
+		},
+		rateLimitRule{
+			name:  "login_key",
+			limit: domain.RateLimit(conf.LoginKey),
+			key:   loginKeyFromLinkRequest,
+		},
+		rateLimitRule{
+			name:  "refresh",
+			limit: domain.RateLimit(conf.Refresh),
+			key:   ipKeyForRoutes("/auth/refresh"),
+		},
+	)
+}
+
+// newUserRateLimitMiddleware limits the requests per user authenticated by the bearer auth middleware.
+func (f HTTPServerFactory) newUserRateLimitMiddleware(rateLimitUsecase usecases.RateLimitUsecase) oapi.MiddlewareFunc {
+	conf := f.cfg.RateLimitConfig
+	if !conf.Enabled {
+		return passThroughMiddleware
+	}
+
+	return newRateLimitMiddleware(rateLimitUsecase, rateLimitRule{
+		name:  "user",
+		limit: domain.RateLimit(conf.User),
+		key: func(r *http.Request) (string, bool) {
+			authenticated, ok := getAuthenticatedUser(r.Context())
+			if !ok {
+				return "", false
+			}
+			return strconv.FormatInt(int64(authenticated.UserID), 10), true
+		},
+	})
+}
+
+func newRateLimitMiddleware(rateLimitUsecase usecases.RateLimitUsecase, rules ...rateLimitRule) oapi.MiddlewareFunc {
+	enabledRules := make([]rateLimitRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.limit.Enabled() {
+			enabledRules = append(enabledRules, rule)
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			for _, rule := range enabledRules {
+				key, ok := rule.key(r)
+				if !ok {
+					continue
+				}
+
+				result, err := rateLimitUsecase.Take(ctx, rule.name+":"+key, rule.limit)
+				if err != nil {
+					httplib.RenderInternalServerError(ctx, w, err)
+					return
+				}
+
+				if !result.Allowed {
+					renderTooManyRequests(ctx, w, result.RetryAfter, serrors.Errorf("rate limit exceeded: %s", rule.name))
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func passThroughMiddleware(next http.Handler) http.Handler {
+	return next
+}
+
+// ipKeyForRoutes returns the key function that uses the IP address of the client for the requests to the routes.
+func ipKeyForRoutes(patterns ...string) func(r *http.Request) (string, bool) {
+	routes := make(map[string]struct{}, len(patterns))
+	for _, pattern := range patterns {
+		routes[pattern] = struct{}{}
+	}
+
+	return func(r *http.Request) (string, bool) {
+		if _, ok := routes[chi.RouteContext(r.Context()).RoutePattern()]; !ok {
+			return "", false
+		}
+
+		log := httplib.GetRequestLogFromContext(r.Context())
+		ip := log.GetIP()
+		if ip == nil {
+			return "", false
+		}
+		return ip.String(), true
+	}
+}
+
+// loginKeyFromLinkRequest reads the login key from the body of the link request and restores the body for the handler.
+func loginKeyFromLinkRequest(r *http.Request) (string, bool) {
+	if r.Method != http.MethodPost || chi.RouteContext(r.Context()).RoutePattern() != "/auth/oauth/{provider}/link" {
+		return "", false
+	}
+
+	peeked, err := io.ReadAll(io.LimitReader(r.Body, rateLimitMaxPeekedBodySize))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(peeked), r.Body), r.Body}
+	if err != nil {
+		return "", false
+	}
+
+	var req oapi.OAuthLinkRequest
+	if err := json.Unmarshal(peeked, &req); err != nil {
+		return "", false
+	}
+
+	loginKey, err := domain.ParseLoginKey(req.LoginKey)
+	if err != nil {
+		return "", false // the handler rejects the malformed login key
+	}
+	return loginKey.String(), true
+}
+
+func renderTooManyRequests(ctx context.Context, w http.ResponseWriter, retryAfter time.Duration, cause error) {
+	seconds := max(int64(math.Ceil(retryAfter.Seconds())), 1)
+	w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+	w.WriteHeader(http.StatusTooManyRequests)
+
+	if resPtr := httplib.GetResponseLogPtrFromContext(ctx); resPtr != nil {
+		*resPtr = httplib.ResponseLog{
+			StatusCode:  http.StatusTooManyRequests,
+			Error:       cause,
+			HandlerInfo: httplib.NewHandlerInfo(1),
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/Siroshun09/go-httplib"
+	"github.com/Siroshun09/logs"
+	"github.com/Siroshun09/serrors"
+	"github.com/gofrs/uuid/v5"
+	"github.com/okocraft/auth-service/internal/handler/http/oapi"
+	"github.com/okocraft/auth-service/internal/usecases"
+)
+
+type serviceHandler struct {
+	authUsecase usecases.AuthUsecase
+	userUsecase usecases.UserUsecase
+}
+
+func newServiceHandler(authUsecase usecases.AuthUsecase, userUsecase usecases.UserUsecase) serviceHandler {
+	return serviceHandler{
+		authUsecase: authUsecase,
+		userUsecase: userUsecase,
+	}
+}
+
+func (h serviceHandler) IssueLoginKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := httplib.DecodeJSONRequestBody[oapi.IssueLoginKeyRequest](r)
+	if err != nil {
+		httplib.RenderBadRequest(ctx, w, err)
+		return
+	}
+
+	userUUID := uuid.UUID(req.Uuid)
+	if userUUID.IsNil() {
+		httplib.RenderBadRequest(ctx, w, serrors.New("uuid is nil"))
+		return
+	}
+
+	userID, err := h.userUsecase.GetOrCreateUserByUUID(ctx, userUUID)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	loginKey, expiresAt, err := h.authUsecase.CreateLoginKey(ctx, userID)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	res, err := httplib.JSONResponse(oapi.IssueLoginKeyResponse{
+		LoginKey:  loginKey.String(),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	err = httplib.RenderOKWithBody(ctx, w, res)
+	if err != nil {
+		logs.Error(ctx, err)
+	}
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/Siroshun09/go-httplib"
+	"github.com/Siroshun09/serrors"
+	"github.com/okocraft/auth-service/internal/handler/http/oapi"
+)
+
+// newServiceAPIKeyAuthMiddleware checks X-API-Key for the operations that require oapi.ServiceApiKeyAuthScopes.
+func (f HTTPServerFactory) newServiceAPIKeyAuthMiddleware(next http.Handler) http.Handler {
+	return newAPIKeyAuthMiddleware(oapi.ServiceApiKeyAuthScopes, "X-API-Key", f.cfg.ServiceAuthConfig.APIKeys)(next)
+}
+
+// newAdminAPIKeyAuthMiddleware checks X-Admin-API-Key for the operations that require oapi.AdminApiKeyAuthScopes.
+func (f HTTPServerFactory) newAdminAPIKeyAuthMiddleware(next http.Handler) http.Handler {
+	return newAPIKeyAuthMiddleware(oapi.AdminApiKeyAuthScopes, "X-Admin-API-Key", f.cfg.AdminAuthConfig.APIKeys)(next)
+}
+
+func newAPIKeyAuthMiddleware(scopesKey string, header string, apiKeys []string) oapi.MiddlewareFunc {
+	hashes := make([][sha256.Size]byte, 0, len(apiKeys))
+	for _, key := range apiKeys {
+		hashes = append(hashes, sha256.Sum256([]byte(key)))
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			if ctx.Value(scopesKey) == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			apiKey := r.Header.Get(header)
+			if apiKey == "" {
+				httplib.RenderUnauthorized(ctx, w, serrors.New("api key not found"))
+				return
+			}
+
+			// compares the hashes so that the comparison time does not depend on the length of the keys
+			hash := sha256.Sum256([]byte(apiKey))
+			matched := 0
+			for _, expected := range hashes {
+				matched |= subtle.ConstantTimeCompare(hash[:], expected[:])
+			}
+
+			if matched != 1 {
+				httplib.RenderUnauthorized(ctx, w, serrors.New("invalid api key"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Siroshun09/go-httplib"
+	"github.com/Siroshun09/logs"
+	"github.com/Siroshun09/serrors"
+	"github.com/gofrs/uuid/v5"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/okocraft/auth-service/internal/domain"
+	"github.com/okocraft/auth-service/internal/handler/http/oapi"
+	"github.com/okocraft/auth-service/internal/usecases"
+	"github.com/okocraft/authlib/user"
+)
+
+type sessionHandler struct {
+	sessionUsecase   usecases.SessionUsecase
+	accessLogUsecase usecases.AccessLogUsecase
+}
+
+func newSessionHandler(sessionUsecase usecases.SessionUsecase, accessLogUsecase usecases.AccessLogUsecase) sessionHandler {
+	return sessionHandler{
+		sessionUsecase:   sessionUsecase,
+		accessLogUsecase: accessLogUsecase,
+	}
+}
+
+func (h sessionHandler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authenticated, ok := getAuthenticatedUser(ctx)
+	if !ok {
+		httplib.RenderUnauthorized(ctx, w, serrors.New("not authenticated"))
+		return
+	}
+
+	sessions, err := h.sessionUsecase.GetSessions(ctx, authenticated.UserID)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	res, err := httplib.JSONResponse(newSessionListResponse(sessions, authenticated.LoginID))
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	err = httplib.RenderOKWithBody(ctx, w, res)
+	if err != nil {
+		logs.Error(ctx, err)
+	}
+}
+
+func newSessionListResponse(sessions []domain.Session, currentLoginID uuid.UUID) oapi.SessionListResponse {
+	body := oapi.SessionListResponse{Sessions: make([]oapi.Session, 0, len(sessions))}
+	for _, session := range sessions {
+		var ip string
+		if session.IP != nil {
+			ip = session.IP.String()
+		}
+
+		body.Sessions = append(body.Sessions, oapi.Session{
+			Id:              openapi_types.UUID(session.LoginID),
+			LoggedInAt:      session.LoggedInAt,
+			LastRefreshedAt: session.LastRefreshedAt,
+			Ip:              ip,
+			UserAgent:       session.UserAgent,
+			Current:         session.LoginID == currentLoginID,
+		})
+	}
+	return body
+}
+
+func (h sessionHandler) RevokeSession(w http.ResponseWriter, r *http.Request, sessionId openapi_types.UUID) {
+	ctx := r.Context()
+
+	authenticated, ok := getAuthenticatedUser(ctx)
+	if !ok {
+		httplib.RenderUnauthorized(ctx, w, serrors.New("not authenticated"))
+		return
+	}
+
+	loginID := uuid.UUID(sessionId)
+	err := h.sessionUsecase.RevokeSession(ctx, authenticated.UserID, loginID)
+	if errors.Is(err, domain.SessionNotFoundError) {
+		httplib.RenderNotFound(ctx, w, err)
+		return
+	} else if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	if err := saveRevocationAccessLogs(r, h.accessLogUsecase, authenticated.UserID, domain.AccessLogActionTypeSessionRevoked, loginID); err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	httplib.RenderNoContent(ctx, w)
+}
+
+func (h sessionHandler) RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authenticated, ok := getAuthenticatedUser(ctx)
+	if !ok {
+		httplib.RenderUnauthorized(ctx, w, serrors.New("not authenticated"))
+		return
+	}
+
+	revoked, err := h.sessionUsecase.RevokeOtherSessions(ctx, authenticated.UserID, authenticated.LoginID)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	if err := saveRevocationAccessLogs(r, h.accessLogUsecase, authenticated.UserID, domain.AccessLogActionTypeSessionRevoked, revoked...); err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	httplib.RenderNoContent(ctx, w)
+}
+
+func saveRevocationAccessLogs(r *http.Request, accessLogUsecase usecases.AccessLogUsecase, userID user.ID, action domain.AccessLogActionType, loginIDs ...uuid.UUID) error {
+	ctx := r.Context()
+	log := httplib.GetRequestLogFromContext(ctx)
+	for _, loginID := range loginIDs {
+		err := accessLogUsecase.SaveAccessLogByUserID(ctx, userID, domain.AccessLogParams{
+			Action:    action,
+			LoginID:   loginID,
+			IP:        log.GetIP(),
+			UserAgent: domain.TruncateUserAgent(log.UserAgent),
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			return serrors.WithStackTrace(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/Siroshun09/go-httplib"
+	"github.com/Siroshun09/logs"
+	"github.com/Siroshun09/serrors"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/okocraft/auth-service/internal/handler/http/oapi"
+	"github.com/okocraft/auth-service/internal/usecases"
+)
+
+type userHandler struct {
+	userUsecase usecases.UserUsecase
+}
+
+func newUserHandler(userUsecase usecases.UserUsecase) userHandler {
+	return userHandler{
+		userUsecase: userUsecase,
+	}
+}
+
+func (h userHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	authenticated, ok := getAuthenticatedUser(ctx)
+	if !ok {
+		httplib.RenderUnauthorized(ctx, w, serrors.New("not authenticated"))
+		return
+	}
+
+	profile, err := h.userUsecase.GetProfile(ctx, authenticated.UserID)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	body := oapi.UserResponse{
+		Uuid:       openapi_types.UUID(profile.User.UUID),
+		CreatedAt:  profile.User.CreatedAt,
+		Identities: make([]oapi.UserIdentity, 0, len(profile.Identities)),
+	}
+	for _, identity := range profile.Identities {
+		body.Identities = append(body.Identities, oapi.UserIdentity{
+			Provider: identity.Provider,
+			Subject:  identity.Subject,
+			LinkedAt: identity.CreatedAt,
+		})
+	}
+
+	res, err := httplib.JSONResponse(body)
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	err = httplib.RenderOKWithBody(ctx, w, res)
+	if err != nil {
+		logs.Error(ctx, err)
+	}
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/Siroshun09/go-httplib"
+	"github.com/Siroshun09/logs"
+	"github.com/okocraft/auth-service/internal/handler/http/oapi"
+	"github.com/okocraft/auth-service/internal/signing"
+)
+
+type wellKnownHandler struct {
+	accessTokenSigner signing.KeySigner
+}
+
+func newWellKnownHandler(accessTokenSigner signing.KeySigner) wellKnownHandler {
+	return wellKnownHandler{
+		accessTokenSigner: accessTokenSigner,
+	}
+}
+
+func (h wellKnownHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	jwks := h.accessTokenSigner.JWKS()
+	keys := make([]oapi.JWK, 0, len(jwks.Keys))
+	for _, key := range jwks.Keys {
+		keys = append(keys, oapi.JWK{
+			Alg: key.Algorithm,
+			Crv: key.Curve,
+			Kid: key.KeyID,
+			Kty: key.KeyType,
+			Use: key.Use,
+			X:   key.X,
+		})
+	}
+
+	res, err := httplib.JSONResponse(oapi.JWKS{Keys: keys})
+	if err != nil {
+		httplib.RenderInternalServerError(ctx, w, err)
+		return
+	}
+
+	// allows verifiers to cache the keys for a while, but short enough to pick up a newly added key
+	w.Header().Set("Cache-Control", "public, max-age=300")
+
+	err = httplib.RenderOKWithBody(ctx, w, res)
+	if err != nil {
+		logs.Error(ctx, err)
+	}
+}
@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Siroshun09/logs"
+	"github.com/okocraft/auth-service/internal/config"
+	"github.com/okocraft/auth-service/internal/usecases"
+)
+
+type CleanupWorker struct {
+	conf    config.CleanupConfig
+	usecase usecases.CleanupUsecase
+}
+
+func NewCleanupWorker(conf config.CleanupConfig, usecase usecases.CleanupUsecase) CleanupWorker {
+	return CleanupWorker{
+		conf:    conf,
+		usecase: usecase,
+	}
+}
+
+// Run deletes expired records every configured interval until ctx is canceled.
+func (w CleanupWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.conf.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w CleanupWorker) runOnce(ctx context.Context) {
+	result, acquired, err := w.usecase.DeleteExpiredRecords(ctx)
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		logs.Error(ctx, err)
+		return
+	}
+
+	if !acquired {
+		logs.Debug(ctx, "cleanup is skipped because another instance is running it")
+		return
+	}
+
+	logs.Info(ctx, fmt.Sprintf(
+		"expired records have been deleted (access tokens: %d, refresh tokens: %d, login keys: %d, used login states: %d, rate limit buckets: %d)",
+		result.DeletedAccessTokens, result.DeletedRefreshTokens, result.DeletedLoginKeys, result.DeletedUsedLoginStates, result.DeletedRateLimitBuckets,
+	))
+}
@@ -0,0 +1,89 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/Siroshun09/serrors"
+	"github.com/okocraft/auth-service/internal/config"
+	"golang.org/x/oauth2"
+)
+
+const discordUserURL = "https://discord.com/api/v10/users/@me"
+
+var discordEndpoint = oauth2.Endpoint{
+	AuthURL:   "https://discord.com/oauth2/authorize",
+	TokenURL:  "https://discord.com/api/oauth2/token",
+	AuthStyle: oauth2.AuthStyleInParams,
+}
+
+// NewDiscordProvider creates the Provider for Discord.
+//
+// Discord does not issue ID tokens, so the subject is the user ID returned from the current user endpoint.
+func NewDiscordProvider(cfg config.DiscordAuthConfig, client *http.Client) Provider {
+	return discordProvider{
+		conf: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       []string{"identify"},
+			Endpoint:     discordEndpoint,
+		},
+		client: client,
+	}
+}
+
+type discordProvider struct {
+	conf   oauth2.Config
+	client *http.Client
+}
+
+type discordUser struct {
+	ID string `json:"id"`
+}
+
+func (p discordProvider) Name() string {
+	return config.DiscordProviderName
+}
+
+func (p discordProvider) AuthCodeURL(_ context.Context, state string, codeVerifier string) (string, error) {
+	return p.conf.AuthCodeURL(state, oauth2.S256ChallengeOption(codeVerifier)), nil
+}
+
+func (p discordProvider) Exchange(ctx context.Context, code string, codeVerifier string) (string, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.client)
+
+	token, err := p.conf.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return "", serrors.WithStackTrace(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discordUserURL, nil)
+	if err != nil {
+		return "", serrors.WithStackTrace(err)
+	}
+
+	res, err := p.conf.Client(ctx, token).Do(req)
+	if err != nil {
+		return "", serrors.WithStackTrace(err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return "", serrors.Errorf("unexpected status code from discord: %d", res.StatusCode)
+	}
+
+	var usr discordUser
+	if err := json.NewDecoder(res.Body).Decode(&usr); err != nil {
+		return "", serrors.WithStackTrace(err)
+	}
+
+	if usr.ID == "" {
+		return "", serrors.New("discord user id is empty")
+	}
+
+	return usr.ID, nil
+}
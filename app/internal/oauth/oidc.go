@@ -0,0 +1,108 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Siroshun09/serrors"
+	"github.com/okocraft/auth-service/internal/config"
+	"github.com/okocraft/auth-service/internal/oidc"
+	"golang.org/x/oauth2"
+)
+
+// NewOIDCProvider creates the Provider for OpenID Connect compliant identity providers.
+//
+// The provider metadata is discovered on first use so that the server can start even if the provider is unreachable.
+func NewOIDCProvider(cfg config.OIDCProviderConfig, client *http.Client) Provider {
+	return &oidcProvider{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+type oidcProvider struct {
+	cfg    config.OIDCProviderConfig
+	client *http.Client
+
+	mu       sync.Mutex
+	conf     *oauth2.Config
+	verifier oidc.IDTokenVerifier
+}
+
+func (p *oidcProvider) Name() string {
+	return p.cfg.Name
+}
+
+func (p *oidcProvider) AuthCodeURL(ctx context.Context, state string, codeVerifier string) (string, error) {
+	conf, _, err := p.discover(ctx)
+	if err != nil {
+		return "", serrors.WithStackTrace(err)
+	}
+
+	return conf.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(codeVerifier)), nil
+}
+
+func (p *oidcProvider) Exchange(ctx context.Context, code string, codeVerifier string) (string, error) {
+	conf, verifier, err := p.discover(ctx)
+	if err != nil {
+		return "", serrors.WithStackTrace(err)
+	}
+
+	token, err := conf.Exchange(context.WithValue(ctx, oauth2.HTTPClient, p.client), code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return "", serrors.WithStackTrace(err)
+	}
+
+	idToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return "", serrors.WithStackTrace(ErrMissingIDToken)
+	}
+
+	claims, err := verifier.Verify(ctx, idToken)
+	if err != nil {
+		return "", serrors.WithStackTrace(err)
+	}
+
+	return claims.Subject, nil
+}
+
+func (p *oidcProvider) discover(ctx context.Context) (*oauth2.Config, oidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conf != nil {
+		return p.conf, p.verifier, nil
+	}
+
+	metadata, err := oidc.Discover(ctx, p.client, p.cfg.Issuer)
+	if err != nil {
+		return nil, nil, serrors.WithStackTrace(err)
+	}
+
+	keySet := oidc.NewRemoteKeySet(oidc.RemoteKeySetConfig{
+		JWKSURL:            metadata.JWKSURI,
+		HTTPClient:         p.client,
+		CacheDuration:      1 * time.Hour,
+		MinRefreshInterval: 1 * time.Minute,
+	})
+
+	p.verifier = oidc.NewIDTokenVerifier(keySet, oidc.IDTokenVerifierConfig{
+		Issuers:  append([]string{metadata.Issuer}, p.cfg.AdditionalIssuers...),
+		ClientID: p.cfg.ClientID,
+		Leeway:   1 * time.Minute,
+	})
+	p.conf = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Scopes:       p.cfg.Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  metadata.AuthorizationEndpoint,
+			TokenURL: metadata.TokenEndpoint,
+		},
+	}
+
+	return p.conf, p.verifier, nil
+}
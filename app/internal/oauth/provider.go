@@ -0,0 +1,49 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/okocraft/auth-service/internal/config"
+)
+
+var ErrMissingIDToken = errors.New("id_token is missing in the token response")
+
+// Provider is an external identity provider that users can log in with.
+type Provider interface {
+	Name() string
+	// AuthCodeURL returns the URL of the provider's consent page that asks for the authorization code with PKCE.
+	AuthCodeURL(ctx context.Context, state string, codeVerifier string) (string, error)
+	// Exchange exchanges the authorization code and returns the subject that identifies the user at the provider.
+	Exchange(ctx context.Context, code string, codeVerifier string) (string, error)
+}
+
+type Registry interface {
+	Get(name string) (Provider, bool)
+}
+
+func NewRegistry(cfg config.OAuthConfig) Registry {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	providers := make(map[string]Provider, len(cfg.Providers)+1)
+	for _, providerConfig := range cfg.Providers {
+		providers[providerConfig.Name] = NewOIDCProvider(providerConfig, client)
+	}
+
+	if cfg.Discord.Enabled {
+		providers[config.DiscordProviderName] = NewDiscordProvider(cfg.Discord, client)
+	}
+
+	return registry{providers: providers}
+}
+
+type registry struct {
+	providers map[string]Provider
+}
+
+func (r registry) Get(name string) (Provider, bool) {
+	provider, ok := r.providers[name]
+	return provider, ok
+}
@@ -0,0 +1,59 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/Siroshun09/serrors"
+)
+
+type ProviderMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Discover fetches the provider metadata from the issuer's .well-known/openid-configuration.
+func Discover(ctx context.Context, client *http.Client, issuer string) (ProviderMetadata, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	wellKnown := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return ProviderMetadata{}, serrors.WithStackTrace(err)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return ProviderMetadata{}, serrors.WithStackTrace(err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return ProviderMetadata{}, serrors.Errorf("unexpected status code from %s: %d", wellKnown, res.StatusCode)
+	}
+
+	var metadata ProviderMetadata
+	if err := json.NewDecoder(res.Body).Decode(&metadata); err != nil {
+		return ProviderMetadata{}, serrors.WithStackTrace(err)
+	}
+
+	// https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfigurationValidation
+	if metadata.Issuer != issuer {
+		return ProviderMetadata{}, serrors.Errorf("issuer mismatch: expected %s, but got %s", issuer, metadata.Issuer)
+	}
+
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return ProviderMetadata{}, serrors.Errorf("incomplete provider metadata from %s", wellKnown)
+	}
+
+	return metadata, nil
+}
@@ -0,0 +1,74 @@
+package oidc_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/okocraft/auth-service/internal/oidc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscover(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata func(issuer string) map[string]string
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			metadata: func(issuer string) map[string]string {
+				return map[string]string{
+					"issuer":                 issuer,
+					"authorization_endpoint": issuer + "/authorize",
+					"token_endpoint":         issuer + "/token",
+					"jwks_uri":               issuer + "/jwks",
+				}
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error: issuer mismatch",
+			metadata: func(issuer string) map[string]string {
+				return map[string]string{
+					"issuer":                 "https://evil.example.com",
+					"authorization_endpoint": issuer + "/authorize",
+					"token_endpoint":         issuer + "/token",
+					"jwks_uri":               issuer + "/jwks",
+				}
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error: missing jwks_uri",
+			metadata: func(issuer string) map[string]string {
+				return map[string]string{
+					"issuer":                 issuer,
+					"authorization_endpoint": issuer + "/authorize",
+					"token_endpoint":         issuer + "/token",
+				}
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/.well-known/openid-configuration" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(tt.metadata(server.URL))
+			}))
+			t.Cleanup(server.Close)
+
+			got, err := oidc.Discover(t.Context(), server.Client(), server.URL)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+			assert.Equal(t, server.URL+"/jwks", got.JWKSURI)
+		})
+	}
+}
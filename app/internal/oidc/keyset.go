@@ -0,0 +1,234 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Siroshun09/serrors"
+)
+
+var ErrKeyNotFound = errors.New("key not found in jwks")
+
+type KeySet interface {
+	GetKey(ctx context.Context, kid string) (any, error)
+}
+
+type RemoteKeySetConfig struct {
+	JWKSURL    string
+	HTTPClient *http.Client
+	// CacheDuration is used when the JWKS response does not have a max-age directive.
+	CacheDuration time.Duration
+	// MinRefreshInterval limits how often an unknown kid can trigger refetching the JWKS.
+	MinRefreshInterval time.Duration
+}
+
+func NewRemoteKeySet(cfg RemoteKeySetConfig) KeySet {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &remoteKeySet{
+		jwksURL:            cfg.JWKSURL,
+		client:             client,
+		cacheDuration:      cfg.CacheDuration,
+		minRefreshInterval: cfg.MinRefreshInterval,
+	}
+}
+
+type remoteKeySet struct {
+	jwksURL            string
+	client             *http.Client
+	cacheDuration      time.Duration
+	minRefreshInterval time.Duration
+
+	mu          sync.Mutex
+	keys        map[string]any
+	expiresAt   time.Time
+	lastFetched time.Time
+}
+
+func (s *remoteKeySet) GetKey(ctx context.Context, kid string) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	if s.keys != nil && now.Before(s.expiresAt) {
+		if key, ok := s.keys[kid]; ok {
+			return key, nil
+		}
+
+		// the key may have been rotated, but avoid hammering the jwks endpoint with unknown kids
+		if now.Sub(s.lastFetched) < s.minRefreshInterval {
+			return nil, serrors.WithStackTrace(ErrKeyNotFound)
+		}
+	}
+
+	if err := s.refresh(ctx, now); err != nil {
+		return nil, serrors.WithStackTrace(err)
+	}
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, serrors.WithStackTrace(ErrKeyNotFound)
+	}
+	return key, nil
+}
+
+func (s *remoteKeySet) refresh(ctx context.Context, now time.Time) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.jwksURL, nil)
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return serrors.Errorf("unexpected status code from jwks endpoint: %d", res.StatusCode)
+	}
+
+	var jwks jsonWebKeySet
+	if err := json.NewDecoder(res.Body).Decode(&jwks); err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	keys := make(map[string]any, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			return serrors.Errorf("failed to parse jwk (kid: %s): %w", jwk.KeyID, err)
+		} else if key == nil {
+			continue // unsupported key type
+		}
+		keys[jwk.KeyID] = key
+	}
+
+	s.keys = keys
+	s.lastFetched = now
+	s.expiresAt = now.Add(getCacheDuration(res.Header.Get("Cache-Control"), s.cacheDuration))
+	return nil
+}
+
+func getCacheDuration(cacheControl string, defaultDuration time.Duration) time.Duration {
+	for directive := range strings.SplitSeq(cacheControl, ",") {
+		value, ok := strings.CutPrefix(strings.TrimSpace(directive), "max-age=")
+		if !ok {
+			continue
+		}
+
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			break
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultDuration
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (any, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		} else if !e.IsInt64() || e.Int64() > int64(^uint32(0)>>1) {
+			return nil, errors.New("invalid rsa exponent")
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, nil
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("ec point is not on the curve")
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Curve != "Ed25519" {
+			return nil, nil
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(k.X, "="))
+		if err != nil {
+			return nil, err
+		} else if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid ed25519 public key size")
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, nil
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
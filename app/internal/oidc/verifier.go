@@ -0,0 +1,100 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/Siroshun09/serrors"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var supportedSigningMethods = []string{
+	jwt.SigningMethodRS256.Alg(),
+	jwt.SigningMethodRS384.Alg(),
+	jwt.SigningMethodRS512.Alg(),
+	jwt.SigningMethodES256.Alg(),
+	jwt.SigningMethodES384.Alg(),
+	jwt.SigningMethodES512.Alg(),
+}
+
+type IDTokenClaims struct {
+	Issuer          string
+	Subject         string
+	Audience        []string
+	AuthorizedParty string
+	ExpiresAt       time.Time
+}
+
+type IDTokenVerifier interface {
+	Verify(ctx context.Context, rawIDToken string) (IDTokenClaims, error)
+}
+
+type IDTokenVerifierConfig struct {
+	// Issuers is the list of accepted iss values. Google uses both "https://accounts.google.com" and "accounts.google.com".
+	Issuers  []string
+	ClientID string
+	Leeway   time.Duration
+}
+
+func NewIDTokenVerifier(keySet KeySet, cfg IDTokenVerifierConfig) IDTokenVerifier {
+	return &idTokenVerifier{
+		keySet: keySet,
+		cfg:    cfg,
+	}
+}
+
+type idTokenVerifier struct {
+	keySet KeySet
+	cfg    IDTokenVerifierConfig
+}
+
+type idTokenJWTClaims struct {
+	jwt.RegisteredClaims
+	AuthorizedParty string `json:"azp,omitempty"`
+}
+
+func (v idTokenVerifier) Verify(ctx context.Context, rawIDToken string) (IDTokenClaims, error) {
+	var claims idTokenJWTClaims
+	_, err := jwt.ParseWithClaims(rawIDToken, &claims, func(token *jwt.Token) (any, error) {
+		kid, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, errors.New("missing kid header")
+		}
+		return v.keySet.GetKey(ctx, kid)
+	},
+		jwt.WithValidMethods(supportedSigningMethods),
+		jwt.WithAudience(v.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(v.cfg.Leeway),
+	)
+	if err != nil {
+		return IDTokenClaims{}, serrors.WithStackTrace(err)
+	}
+
+	if !slices.Contains(v.cfg.Issuers, claims.Issuer) {
+		return IDTokenClaims{}, serrors.Errorf("unexpected issuer: %s", claims.Issuer)
+	}
+
+	if claims.Subject == "" {
+		return IDTokenClaims{}, serrors.New("missing sub claim")
+	}
+
+	// https://openid.net/specs/openid-connect-core-1_0.html#IDTokenValidation
+	if len(claims.Audience) > 1 && claims.AuthorizedParty == "" {
+		return IDTokenClaims{}, serrors.New("missing azp claim for multiple audiences")
+	}
+	if claims.AuthorizedParty != "" && claims.AuthorizedParty != v.cfg.ClientID {
+		return IDTokenClaims{}, serrors.Errorf("unexpected azp: %s", claims.AuthorizedParty)
+	}
+
+	return IDTokenClaims{
+		Issuer:          claims.Issuer,
+		Subject:         claims.Subject,
+		Audience:        claims.Audience,
+		AuthorizedParty: claims.AuthorizedParty,
+		ExpiresAt:       claims.ExpiresAt.Time,
+	}, nil
+}
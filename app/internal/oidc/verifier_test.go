@@ -0,0 +1,267 @@
+package oidc_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/okocraft/auth-service/internal/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIssuer   = "https://issuer.example.com"
+	testClientID = "test-client-id"
+)
+
+type testKey struct {
+	kid string
+	key *rsa.PrivateKey
+}
+
+// testJWKSServer is a stand-in for the JWKS endpoint of an OpenID provider.
+type testJWKSServer struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	keys       []testKey
+	fetchCount int
+}
+
+func newTestJWKSServer(t *testing.T, keys ...testKey) *testJWKSServer {
+	s := &testJWKSServer{keys: keys}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.fetchCount++
+
+		jwks := map[string][]map[string]string{"keys": {}}
+		for _, k := range s.keys {
+			jwks["keys"] = append(jwks["keys"], map[string]string{
+				"kty": "RSA",
+				"kid": k.kid,
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(k.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.key.E)).Bytes()),
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		_ = json.NewEncoder(w).Encode(jwks)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testJWKSServer) setKeys(keys ...testKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func (s *testJWKSServer) getFetchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetchCount
+}
+
+func newTestKey(t *testing.T, kid string) testKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return testKey{kid: kid, key: key}
+}
+
+func signIDToken(t *testing.T, key testKey, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = key.kid
+	signed, err := token.SignedString(key.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss": testIssuer,
+		"sub": "1234567890",
+		"aud": testClientID,
+		"azp": testClientID,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+}
+
+func newTestVerifier(server *testJWKSServer) oidc.IDTokenVerifier {
+	keySet := oidc.NewRemoteKeySet(oidc.RemoteKeySetConfig{
+		JWKSURL:       server.URL,
+		HTTPClient:    server.Client(),
+		CacheDuration: time.Hour,
+	})
+	return oidc.NewIDTokenVerifier(keySet, oidc.IDTokenVerifierConfig{
+		Issuers:  []string{testIssuer},
+		ClientID: testClientID,
+	})
+}
+
+func TestIDTokenVerifier_Verify(t *testing.T) {
+	key := newTestKey(t, "key-1")
+	unknownKey := newTestKey(t, "unknown")
+
+	tests := []struct {
+		name    string
+		key     testKey
+		claims  func(claims jwt.MapClaims)
+		wantSub string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success",
+			key:     key,
+			claims:  func(jwt.MapClaims) {},
+			wantSub: "1234567890",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success: multiple audiences with azp",
+			key:  key,
+			claims: func(claims jwt.MapClaims) {
+				claims["aud"] = []string{testClientID, "another-client"}
+			},
+			wantSub: "1234567890",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error: multiple audiences without azp",
+			key:  key,
+			claims: func(claims jwt.MapClaims) {
+				claims["aud"] = []string{testClientID, "another-client"}
+				delete(claims, "azp")
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error: unexpected azp",
+			key:  key,
+			claims: func(claims jwt.MapClaims) {
+				claims["azp"] = "another-client"
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error: unexpected audience",
+			key:  key,
+			claims: func(claims jwt.MapClaims) {
+				claims["aud"] = "another-client"
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error: unexpected issuer",
+			key:  key,
+			claims: func(claims jwt.MapClaims) {
+				claims["iss"] = "https://evil.example.com"
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error: expired",
+			key:  key,
+			claims: func(claims jwt.MapClaims) {
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error: missing exp",
+			key:  key,
+			claims: func(claims jwt.MapClaims) {
+				delete(claims, "exp")
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error: missing sub",
+			key:  key,
+			claims: func(claims jwt.MapClaims) {
+				delete(claims, "sub")
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error: signed by unknown key",
+			key:     unknownKey,
+			claims:  func(jwt.MapClaims) {},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error: signed by unknown key with known kid",
+			key:     testKey{kid: key.kid, key: unknownKey.key},
+			claims:  func(jwt.MapClaims) {},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestJWKSServer(t, key)
+			verifier := newTestVerifier(server)
+
+			claims := validClaims()
+			tt.claims(claims)
+
+			got, err := verifier.Verify(t.Context(), signIDToken(t, tt.key, claims))
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.wantSub, got.Subject)
+		})
+	}
+}
+
+func TestIDTokenVerifier_Verify_UnsignedToken(t *testing.T) {
+	server := newTestJWKSServer(t, newTestKey(t, "key-1"))
+	verifier := newTestVerifier(server)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims())
+	token.Header["kid"] = "key-1"
+	signed, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	_, err = verifier.Verify(t.Context(), signed)
+	assert.Error(t, err)
+}
+
+func TestIDTokenVerifier_Verify_KeyRotation(t *testing.T) {
+	oldKey := newTestKey(t, "old")
+	newKey := newTestKey(t, "new")
+
+	server := newTestJWKSServer(t, oldKey)
+	verifier := newTestVerifier(server)
+
+	_, err := verifier.Verify(t.Context(), signIDToken(t, oldKey, validClaims()))
+	require.NoError(t, err)
+
+	_, err = verifier.Verify(t.Context(), signIDToken(t, oldKey, validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, 1, server.getFetchCount(), "jwks should be cached")
+
+	server.setKeys(newKey)
+
+	_, err = verifier.Verify(t.Context(), signIDToken(t, newKey, validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.getFetchCount(), "unknown kid should trigger refetching jwks")
+
+	_, err = verifier.Verify(t.Context(), signIDToken(t, oldKey, validClaims()))
+	assert.Error(t, err, "rotated out key should no longer be accepted")
+}
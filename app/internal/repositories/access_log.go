@@ -2,7 +2,13 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"math"
+	"net"
+	"time"
 
+	"github.com/Siroshun09/serrors"
+	"github.com/gofrs/uuid/v5"
 	"github.com/okocraft/auth-service/internal/domain"
 	"github.com/okocraft/auth-service/internal/repositories/database"
 	"github.com/okocraft/auth-service/internal/repositories/queries"
@@ -11,6 +17,11 @@ import (
 
 type AccessLogRepository interface {
 	SaveAccessLog(ctx context.Context, conn database.Connection, userID user.ID, accessLog domain.AccessLogParams) error
+	GetLatestAccessLogsOfLogins(ctx context.Context, conn database.Connection, userID user.ID) ([]domain.AccessLog, error)
+	GetAccessLogsByUserID(ctx context.Context, conn database.Connection, userID user.ID, before int64, limit int32) ([]domain.AccessLog, error)
+	GetAccessLogsByUserIDAndLoginID(ctx context.Context, conn database.Connection, userID user.ID, loginID uuid.UUID, before int64, limit int32) ([]domain.AccessLog, error)
+	GetFailedAccessLogs(ctx context.Context, conn database.Connection, before int64, limit int32) ([]domain.AccessLog, error)
+	GetFailedAccessLogsByIP(ctx context.Context, conn database.Connection, ip net.IP, before int64, limit int32) ([]domain.AccessLog, error)
 }
 
 func NewAccessLogRepository() AccessLogRepository {
@@ -21,9 +32,10 @@ type accessLogRepository struct{}
 
 func (r accessLogRepository) SaveAccessLog(ctx context.Context, conn database.Connection, userID user.ID, accessLog domain.AccessLogParams) error {
 	err := conn.Queries().InsertAccessLog(ctx, queries.InsertAccessLogParams{
-		UserID:     int32(userID),
+		UserID:     toNullUserID(userID),
 		ActionType: int8(accessLog.Action),
-		LoginID:    accessLog.LoginID.Bytes(),
+		Reason:     int8(accessLog.Reason),
+		LoginID:    toNullLoginID(accessLog.LoginID),
 		Ip:         accessLog.IP,
 		UserAgent:  accessLog.UserAgent,
 		CreatedAt:  accessLog.CreatedAt,
@@ -33,3 +45,188 @@ func (r accessLogRepository) SaveAccessLog(ctx context.Context, conn database.Co
 	}
 	return nil
 }
+
+func (r accessLogRepository) GetLatestAccessLogsOfLogins(ctx context.Context, conn database.Connection, userID user.ID) ([]domain.AccessLog, error) {
+	rows, err := conn.Queries().GetLatestAccessLogsOfLoginsByUserID(ctx, toNullUserID(userID))
+	if err != nil {
+		return nil, database.NewDBErrorWithStackTrace(err)
+	}
+
+	accessLogs := make([]domain.AccessLog, 0, len(rows))
+	for _, row := range rows {
+		loginID, err := fromNullLoginID(row.LoginID)
+		if err != nil {
+			return nil, serrors.WithStackTrace(err)
+		}
+
+		accessLogs = append(accessLogs, domain.AccessLog{
+			UserID:    userID,
+			LoginID:   loginID,
+			IP:        row.Ip,
+			UserAgent: row.UserAgent,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return accessLogs, nil
+}
+
+func (r accessLogRepository) GetAccessLogsByUserID(ctx context.Context, conn database.Connection, userID user.ID, before int64, limit int32) ([]domain.AccessLog, error) {
+	rows, err := conn.Queries().GetAccessLogsByUserID(ctx, queries.GetAccessLogsByUserIDParams{
+		UserID: toNullUserID(userID),
+		ID:     toBeforeCursor(before),
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, database.NewDBErrorWithStackTrace(err)
+	}
+
+	accessLogs := make([]domain.AccessLog, 0, len(rows))
+	for _, row := range rows {
+		accessLog, err := toDomainAccessLog(row.ID, row.ActionType, row.Reason, row.LoginID, row.Ip, row.UserAgent, row.CreatedAt)
+		if err != nil {
+			return nil, serrors.WithStackTrace(err)
+		}
+		accessLog.UserID = userID
+		accessLogs = append(accessLogs, accessLog)
+	}
+	return accessLogs, nil
+}
+
+func (r accessLogRepository) GetAccessLogsByUserIDAndLoginID(ctx context.Context, conn database.Connection, userID user.ID, loginID uuid.UUID, before int64, limit int32) ([]domain.AccessLog, error) {
+	rows, err := conn.Queries().GetAccessLogsByUserIDAndLoginID(ctx, queries.GetAccessLogsByUserIDAndLoginIDParams{
+		UserID:  toNullUserID(userID),
+		LoginID: toNullLoginID(loginID),
+		ID:      toBeforeCursor(before),
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, database.NewDBErrorWithStackTrace(err)
+	}
+
+	accessLogs := make([]domain.AccessLog, 0, len(rows))
+	for _, row := range rows {
+		accessLog, err := toDomainAccessLog(row.ID, row.ActionType, row.Reason, row.LoginID, row.Ip, row.UserAgent, row.CreatedAt)
+		if err != nil {
+			return nil, serrors.WithStackTrace(err)
+		}
+		accessLog.UserID = userID
+		accessLogs = append(accessLogs, accessLog)
+	}
+	return accessLogs, nil
+}
+
+func (r accessLogRepository) GetFailedAccessLogs(ctx context.Context, conn database.Connection, before int64, limit int32) ([]domain.AccessLog, error) {
+	rows, err := conn.Queries().GetFailedAccessLogs(ctx, queries.GetFailedAccessLogsParams{
+		ID:    toBeforeCursor(before),
+		Limit: limit,
+	})
+	if err != nil {
+		return nil, database.NewDBErrorWithStackTrace(err)
+	}
+
+	accessLogs := make([]domain.AccessLog, 0, len(rows))
+	for _, row := range rows {
+		accessLog, err := toDomainAccessLog(row.ID, row.ActionType, row.Reason, row.LoginID, row.Ip, row.UserAgent, row.CreatedAt)
+		if err != nil {
+			return nil, serrors.WithStackTrace(err)
+		}
+
+		accessLog.UserID, accessLog.UserUUID, err = fromNullUser(row.UserID, row.UserUuid)
+		if err != nil {
+			return nil, serrors.WithStackTrace(err)
+		}
+
+		accessLogs = append(accessLogs, accessLog)
+	}
+	return accessLogs, nil
+}
+
+func (r accessLogRepository) GetFailedAccessLogsByIP(ctx context.Context, conn database.Connection, ip net.IP, before int64, limit int32) ([]domain.AccessLog, error) {
+	rows, err := conn.Queries().GetFailedAccessLogsByIP(ctx, queries.GetFailedAccessLogsByIPParams{
+		Ip:    ip,
+		ID:    toBeforeCursor(before),
+		Limit: limit,
+	})
+	if err != nil {
+		return nil, database.NewDBErrorWithStackTrace(err)
+	}
+
+	accessLogs := make([]domain.AccessLog, 0, len(rows))
+	for _, row := range rows {
+		accessLog, err := toDomainAccessLog(row.ID, row.ActionType, row.Reason, row.LoginID, row.Ip, row.UserAgent, row.CreatedAt)
+		if err != nil {
+			return nil, serrors.WithStackTrace(err)
+		}
+
+		accessLog.UserID, accessLog.UserUUID, err = fromNullUser(row.UserID, row.UserUuid)
+		if err != nil {
+			return nil, serrors.WithStackTrace(err)
+		}
+
+		accessLogs = append(accessLogs, accessLog)
+	}
+	return accessLogs, nil
+}
+
+func toDomainAccessLog(id int64, actionType int8, reason int8, nullLoginID sql.NullString, ip []byte, userAgent string, createdAt time.Time) (domain.AccessLog, error) {
+	loginID, err := fromNullLoginID(nullLoginID)
+	if err != nil {
+		return domain.AccessLog{}, err
+	}
+
+	return domain.AccessLog{
+		ID:        id,
+		Action:    domain.AccessLogActionType(actionType),
+		Reason:    domain.AccessLogFailureReason(reason),
+		LoginID:   loginID,
+		IP:        ip,
+		UserAgent: userAgent,
+		CreatedAt: createdAt,
+	}, nil
+}
+
+// toBeforeCursor converts the cursor of the first page (0) to the value that matches every row.
+func toBeforeCursor(before int64) int64 {
+	if before <= 0 {
+		return math.MaxInt64
+	}
+	return before
+}
+
+func toNullUserID(userID user.ID) sql.NullInt32 {
+	if userID == 0 {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(userID), Valid: true}
+}
+
+func fromNullUser(userID sql.NullInt32, userUUID sql.NullString) (user.ID, uuid.UUID, error) {
+	if !userID.Valid || !userUUID.Valid {
+		return 0, uuid.Nil, nil
+	}
+
+	ret, err := uuid.FromBytes([]byte(userUUID.String))
+	if err != nil {
+		return 0, uuid.Nil, serrors.WithStackTrace(err)
+	}
+	return user.ID(userID.Int32), ret, nil
+}
+
+func toNullLoginID(loginID uuid.UUID) sql.NullString {
+	if loginID == uuid.Nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(loginID.Bytes()), Valid: true}
+}
+
+func fromNullLoginID(loginID sql.NullString) (uuid.UUID, error) {
+	if !loginID.Valid {
+		return uuid.Nil, nil
+	}
+
+	ret, err := uuid.FromBytes([]byte(loginID.String))
+	if err != nil {
+		return uuid.Nil, serrors.WithStackTrace(err)
+	}
+	return ret, nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/Siroshun09/serrors"
 	"github.com/gofrs/uuid/v5"
 	"github.com/okocraft/auth-service/internal/domain"
 	"github.com/okocraft/auth-service/internal/repositories/database"
@@ -17,10 +18,19 @@ type AuthRepository interface {
 	SaveRefreshToken(ctx context.Context, conn database.Connection, userID user.ID, jti uuid.UUID, loginID uuid.UUID, createdAt time.Time) error
 	SaveAccessToken(ctx context.Context, conn database.Connection, refreshTokenID int64, jti uuid.UUID, createdAt time.Time) error
 	GetUserIDAndRefreshTokenIDFromJTI(ctx context.Context, conn database.Connection, jti uuid.UUID) (user.ID, int64, error)
+	GetAccessTokenOwnerByJTI(ctx context.Context, conn database.Connection, jti uuid.UUID) (domain.AccessTokenOwner, error)
+	ConsumeRefreshToken(ctx context.Context, conn database.Connection, refreshTokenID int64, consumedAt time.Time) error
+	GetSessionsByUserID(ctx context.Context, conn database.Connection, userID user.ID, since time.Time) ([]domain.Session, error)
+	ExistsLoginByUserID(ctx context.Context, conn database.Connection, userID user.ID, loginID uuid.UUID) (bool, error)
 	DeleteAccessTokensByLoginID(ctx context.Context, conn database.Connection, loginID uuid.UUID) error
 	DeleteRefreshTokensByLoginID(ctx context.Context, conn database.Connection, loginID uuid.UUID) error
-	DeleteExpiredAccessTokens(ctx context.Context, conn database.Connection, expiredAt time.Time) (int64, error)
-	DeleteExpiredRefreshTokens(ctx context.Context, conn database.Connection, expiredAt time.Time) (int64, error)
+	DeleteAccessTokensByUserID(ctx context.Context, conn database.Connection, userID user.ID) error
+	DeleteRefreshTokensByUserID(ctx context.Context, conn database.Connection, userID user.ID) error
+	DeleteExpiredAccessTokens(ctx context.Context, conn database.Connection, expiredAt time.Time, limit int32) (int64, error)
+	DeleteExpiredRefreshTokens(ctx context.Context, conn database.Connection, expiredAt time.Time, limit int32) (int64, error)
+	// ConsumeLoginState records the state JWT as used, or returns domain.LoginStateAlreadyUsedError if it is already used.
+	ConsumeLoginState(ctx context.Context, conn database.Connection, jti uuid.UUID, expiresAt time.Time) error
+	DeleteExpiredUsedLoginStates(ctx context.Context, conn database.Connection, now time.Time, limit int32) (int64, error)
 }
 
 func NewAuthRepository() AuthRepository {
@@ -64,6 +74,84 @@ func (r authRepository) GetUserIDAndRefreshTokenIDFromJTI(ctx context.Context, c
 	return user.ID(row.UserID), row.ID, nil
 }
 
+func (r authRepository) GetAccessTokenOwnerByJTI(ctx context.Context, conn database.Connection, jti uuid.UUID) (domain.AccessTokenOwner, error) {
+	q := conn.Queries()
+	row, err := q.GetUserByAccessTokenJTI(ctx, jti.Bytes())
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.AccessTokenOwner{}, domain.AccessTokenNotFoundError
+	} else if err != nil {
+		return domain.AccessTokenOwner{}, database.NewDBErrorWithStackTrace(err)
+	}
+
+	userUUID, err := uuid.FromBytes(row.UserUuid)
+	if err != nil {
+		return domain.AccessTokenOwner{}, serrors.WithStackTrace(err)
+	}
+
+	loginID, err := uuid.FromBytes(row.LoginID)
+	if err != nil {
+		return domain.AccessTokenOwner{}, serrors.WithStackTrace(err)
+	}
+
+	return domain.AccessTokenOwner{
+		UserID:   user.ID(row.UserID),
+		UserUUID: userUUID,
+		LoginID:  loginID,
+	}, nil
+}
+
+func (r authRepository) ConsumeRefreshToken(ctx context.Context, conn database.Connection, refreshTokenID int64, consumedAt time.Time) error {
+	q := conn.Queries()
+	rows, err := q.ConsumeRefreshToken(ctx, queries.ConsumeRefreshTokenParams{
+		ConsumedAt: sql.NullTime{Time: consumedAt, Valid: true},
+		ID:         refreshTokenID,
+	})
+	if err != nil {
+		return database.NewDBErrorWithStackTrace(err)
+	} else if rows == 0 {
+		return domain.RefreshTokenAlreadyConsumedError
+	}
+	return nil
+}
+
+func (r authRepository) GetSessionsByUserID(ctx context.Context, conn database.Connection, userID user.ID, since time.Time) ([]domain.Session, error) {
+	q := conn.Queries()
+	rows, err := q.GetSessionsByUserID(ctx, queries.GetSessionsByUserIDParams{
+		UserID:    int32(userID),
+		CreatedAt: since,
+	})
+	if err != nil {
+		return nil, database.NewDBErrorWithStackTrace(err)
+	}
+
+	sessions := make([]domain.Session, 0, len(rows))
+	for _, row := range rows {
+		loginID, err := uuid.FromBytes(row.LoginID)
+		if err != nil {
+			return nil, serrors.WithStackTrace(err)
+		}
+
+		sessions = append(sessions, domain.Session{
+			LoginID:         loginID,
+			LoggedInAt:      row.LoggedInAt,
+			LastRefreshedAt: row.LastRefreshedAt,
+		})
+	}
+	return sessions, nil
+}
+
+func (r authRepository) ExistsLoginByUserID(ctx context.Context, conn database.Connection, userID user.ID, loginID uuid.UUID) (bool, error) {
+	q := conn.Queries()
+	found, err := q.ExistsRefreshTokenByUserIDAndLoginID(ctx, queries.ExistsRefreshTokenByUserIDAndLoginIDParams{
+		UserID:  int32(userID),
+		LoginID: loginID.Bytes(),
+	})
+	if err != nil {
+		return false, database.NewDBErrorWithStackTrace(err)
+	}
+	return found, nil
+}
+
 func (r authRepository) DeleteAccessTokensByLoginID(ctx context.Context, conn database.Connection, loginID uuid.UUID) error {
 	q := conn.Queries()
 	err := q.DeleteAccessTokensByLoginID(ctx, loginID.Bytes())
@@ -82,18 +170,68 @@ func (r authRepository) DeleteRefreshTokensByLoginID(ctx context.Context, conn d
 	return nil
 }
 
-func (r authRepository) DeleteExpiredAccessTokens(ctx context.Context, conn database.Connection, expiredAt time.Time) (int64, error) {
+func (r authRepository) DeleteAccessTokensByUserID(ctx context.Context, conn database.Connection, userID user.ID) error {
+	q := conn.Queries()
+	err := q.DeleteAccessTokensByUserID(ctx, int32(userID))
+	if err != nil {
+		return database.NewDBErrorWithStackTrace(err)
+	}
+	return nil
+}
+
+func (r authRepository) DeleteRefreshTokensByUserID(ctx context.Context, conn database.Connection, userID user.ID) error {
 	q := conn.Queries()
-	rows, err := q.DeleteExpiredAccessTokens(ctx, expiredAt)
+	err := q.DeleteRefreshTokensByUserID(ctx, int32(userID))
+	if err != nil {
+		return database.NewDBErrorWithStackTrace(err)
+	}
+	return nil
+}
+
+func (r authRepository) DeleteExpiredAccessTokens(ctx context.Context, conn database.Connection, expiredAt time.Time, limit int32) (int64, error) {
+	q := conn.Queries()
+	rows, err := q.DeleteExpiredAccessTokens(ctx, queries.DeleteExpiredAccessTokensParams{
+		CreatedAt: expiredAt,
+		Limit:     limit,
+	})
 	if err != nil {
 		return 0, database.NewDBErrorWithStackTrace(err)
 	}
 	return rows, nil
 }
 
-func (r authRepository) DeleteExpiredRefreshTokens(ctx context.Context, conn database.Connection, expiredAt time.Time) (int64, error) {
+func (r authRepository) DeleteExpiredRefreshTokens(ctx context.Context, conn database.Connection, expiredAt time.Time, limit int32) (int64, error) {
 	q := conn.Queries()
-	rows, err := q.DeleteExpiredRefreshTokens(ctx, expiredAt)
+	rows, err := q.DeleteExpiredRefreshTokens(ctx, queries.DeleteExpiredRefreshTokensParams{
+		CreatedAt: expiredAt,
+		Limit:     limit,
+	})
+	if err != nil {
+		return 0, database.NewDBErrorWithStackTrace(err)
+	}
+	return rows, nil
+}
+
+func (r authRepository) ConsumeLoginState(ctx context.Context, conn database.Connection, jti uuid.UUID, expiresAt time.Time) error {
+	q := conn.Queries()
+	rows, err := q.InsertUsedLoginState(ctx, queries.InsertUsedLoginStateParams{
+		Jti:       jti.Bytes(),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return database.NewDBErrorWithStackTrace(err)
+	} else if rows == 0 {
+		return domain.LoginStateAlreadyUsedError
+	}
+	return nil
+}
+
+func (r authRepository) DeleteExpiredUsedLoginStates(ctx context.Context, conn database.Connection, now time.Time, limit int32) (int64, error) {
+	q := conn.Queries()
+	rows, err := q.DeleteExpiredUsedLoginStates(ctx, queries.DeleteExpiredUsedLoginStatesParams{
+		ExpiresAt: now,
+		Limit:     limit,
+	})
 	if err != nil {
 		return 0, database.NewDBErrorWithStackTrace(err)
 	}
@@ -23,6 +23,7 @@ type DB interface {
 	Base() *sql.DB
 	Conn() Connection
 	WithTx(ctx context.Context, fn func(ctx context.Context, tx Connection) error) error
+	WithLock(ctx context.Context, name string, fn func(ctx context.Context, conn Connection) error) (bool, error)
 	Close() error
 }
 
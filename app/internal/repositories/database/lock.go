@@ -0,0 +1,42 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Siroshun09/logs"
+	"github.com/Siroshun09/serrors"
+)
+
+// WithLock runs fn while holding the MariaDB named lock (GET_LOCK) so that only one replica executes it at a time.
+//
+// If the lock is already held by another session, fn is not called and false is returned.
+func (db db) WithLock(ctx context.Context, name string, fn func(ctx context.Context, conn Connection) error) (bool, error) {
+	conn, err := db.base.Conn(ctx)
+	if err != nil {
+		return false, serrors.WithStackTrace(err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	var acquired sql.NullInt32
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", name).Scan(&acquired); err != nil {
+		return false, NewDBErrorWithStackTrace(err)
+	}
+	if !acquired.Valid || acquired.Int32 != 1 {
+		return false, nil
+	}
+
+	defer func() {
+		// use a fresh context so the lock is released even if ctx has been canceled
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), "SELECT RELEASE_LOCK(?)", name); err != nil {
+			logs.Error(ctx, NewDBErrorWithStackTrace(err))
+		}
+	}()
+
+	if err := fn(ctx, newConnection(conn)); err != nil {
+		return true, err
+	}
+	return true, nil
+}
@@ -7,27 +7,332 @@ package queries
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
+const getAccessLogsByUserID = `-- name: GetAccessLogsByUserID :many
+SELECT id, action_type, reason, login_id, ip, user_agent, created_at
+FROM users_access_logs
+WHERE user_id = ?
+  AND id < ?
+ORDER BY id DESC
+LIMIT ?
+`
+
+type GetAccessLogsByUserIDParams struct {
+	UserID sql.NullInt32 `db:"user_id"`
+	ID     int64         `db:"id"`
+	Limit  int32         `db:"limit"`
+}
+
+type GetAccessLogsByUserIDRow struct {
+	ID         int64          `db:"id"`
+	ActionType int8           `db:"action_type"`
+	Reason     int8           `db:"reason"`
+	LoginID    sql.NullString `db:"login_id"`
+	Ip         []byte         `db:"ip"`
+	UserAgent  string         `db:"user_agent"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+func (q *Queries) GetAccessLogsByUserID(ctx context.Context, arg GetAccessLogsByUserIDParams) ([]GetAccessLogsByUserIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAccessLogsByUserID, arg.UserID, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAccessLogsByUserIDRow
+	for rows.Next() {
+		var i GetAccessLogsByUserIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ActionType,
+			&i.Reason,
+			&i.LoginID,
+			&i.Ip,
+			&i.UserAgent,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAccessLogsByUserIDAndLoginID = `-- name: GetAccessLogsByUserIDAndLoginID :many
+SELECT id, action_type, reason, login_id, ip, user_agent, created_at
+FROM users_access_logs
+WHERE user_id = ?
+  AND login_id = ?
+  AND id < ?
+ORDER BY id DESC
+LIMIT ?
+`
+
+type GetAccessLogsByUserIDAndLoginIDParams struct {
+	UserID  sql.NullInt32  `db:"user_id"`
+	LoginID sql.NullString `db:"login_id"`
+	ID      int64          `db:"id"`
+	Limit   int32          `db:"limit"`
+}
+
+type GetAccessLogsByUserIDAndLoginIDRow struct {
+	ID         int64          `db:"id"`
+	ActionType int8           `db:"action_type"`
+	Reason     int8           `db:"reason"`
+	LoginID    sql.NullString `db:"login_id"`
+	Ip         []byte         `db:"ip"`
+	UserAgent  string         `db:"user_agent"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+func (q *Queries) GetAccessLogsByUserIDAndLoginID(ctx context.Context, arg GetAccessLogsByUserIDAndLoginIDParams) ([]GetAccessLogsByUserIDAndLoginIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAccessLogsByUserIDAndLoginID,
+		arg.UserID,
+		arg.LoginID,
+		arg.ID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAccessLogsByUserIDAndLoginIDRow
+	for rows.Next() {
+		var i GetAccessLogsByUserIDAndLoginIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ActionType,
+			&i.Reason,
+			&i.LoginID,
+			&i.Ip,
+			&i.UserAgent,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFailedAccessLogs = `-- name: GetFailedAccessLogs :many
+SELECT users_access_logs.id,
+       users_access_logs.user_id,
+       users.uuid AS user_uuid,
+       users_access_logs.action_type,
+       users_access_logs.reason,
+       users_access_logs.login_id,
+       users_access_logs.ip,
+       users_access_logs.user_agent,
+       users_access_logs.created_at
+FROM users_access_logs
+         LEFT JOIN users ON users.id = users_access_logs.user_id
+WHERE users_access_logs.reason <> 0
+  AND users_access_logs.id < ?
+ORDER BY users_access_logs.id DESC
+LIMIT ?
+`
+
+type GetFailedAccessLogsParams struct {
+	ID    int64 `db:"id"`
+	Limit int32 `db:"limit"`
+}
+
+type GetFailedAccessLogsRow struct {
+	ID         int64          `db:"id"`
+	UserID     sql.NullInt32  `db:"user_id"`
+	UserUuid   sql.NullString `db:"user_uuid"`
+	ActionType int8           `db:"action_type"`
+	Reason     int8           `db:"reason"`
+	LoginID    sql.NullString `db:"login_id"`
+	Ip         []byte         `db:"ip"`
+	UserAgent  string         `db:"user_agent"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+func (q *Queries) GetFailedAccessLogs(ctx context.Context, arg GetFailedAccessLogsParams) ([]GetFailedAccessLogsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFailedAccessLogs, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFailedAccessLogsRow
+	for rows.Next() {
+		var i GetFailedAccessLogsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.UserUuid,
+			&i.ActionType,
+			&i.Reason,
+			&i.LoginID,
+			&i.Ip,
+			&i.UserAgent,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFailedAccessLogsByIP = `-- name: GetFailedAccessLogsByIP :many
+SELECT users_access_logs.id,
+       users_access_logs.user_id,
+       users.uuid AS user_uuid,
+       users_access_logs.action_type,
+       users_access_logs.reason,
+       users_access_logs.login_id,
+       users_access_logs.ip,
+       users_access_logs.user_agent,
+       users_access_logs.created_at
+FROM users_access_logs
+         LEFT JOIN users ON users.id = users_access_logs.user_id
+WHERE users_access_logs.ip = ?
+  AND users_access_logs.reason <> 0
+  AND users_access_logs.id < ?
+ORDER BY users_access_logs.id DESC
+LIMIT ?
+`
+
+type GetFailedAccessLogsByIPParams struct {
+	Ip    []byte `db:"ip"`
+	ID    int64  `db:"id"`
+	Limit int32  `db:"limit"`
+}
+
+type GetFailedAccessLogsByIPRow struct {
+	ID         int64          `db:"id"`
+	UserID     sql.NullInt32  `db:"user_id"`
+	UserUuid   sql.NullString `db:"user_uuid"`
+	ActionType int8           `db:"action_type"`
+	Reason     int8           `db:"reason"`
+	LoginID    sql.NullString `db:"login_id"`
+	Ip         []byte         `db:"ip"`
+	UserAgent  string         `db:"user_agent"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+func (q *Queries) GetFailedAccessLogsByIP(ctx context.Context, arg GetFailedAccessLogsByIPParams) ([]GetFailedAccessLogsByIPRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFailedAccessLogsByIP, arg.Ip, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFailedAccessLogsByIPRow
+	for rows.Next() {
+		var i GetFailedAccessLogsByIPRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.UserUuid,
+			&i.ActionType,
+			&i.Reason,
+			&i.LoginID,
+			&i.Ip,
+			&i.UserAgent,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLatestAccessLogsOfLoginsByUserID = `-- name: GetLatestAccessLogsOfLoginsByUserID :many
+SELECT login_id, ip, user_agent, created_at
+FROM users_access_logs
+WHERE id IN (SELECT MAX(id)
+             FROM users_access_logs
+             WHERE users_access_logs.user_id = ?
+               AND users_access_logs.login_id IS NOT NULL
+             GROUP BY login_id)
+`
+
+type GetLatestAccessLogsOfLoginsByUserIDRow struct {
+	LoginID   sql.NullString `db:"login_id"`
+	Ip        []byte         `db:"ip"`
+	UserAgent string         `db:"user_agent"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func (q *Queries) GetLatestAccessLogsOfLoginsByUserID(ctx context.Context, userID sql.NullInt32) ([]GetLatestAccessLogsOfLoginsByUserIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getLatestAccessLogsOfLoginsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLatestAccessLogsOfLoginsByUserIDRow
+	for rows.Next() {
+		var i GetLatestAccessLogsOfLoginsByUserIDRow
+		if err := rows.Scan(
+			&i.LoginID,
+			&i.Ip,
+			&i.UserAgent,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const insertAccessLog = `-- name: InsertAccessLog :exec
-INSERT INTO users_access_logs (user_id, action_type, login_id, ip, user_agent, created_at)
-VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO users_access_logs (user_id, action_type, reason, login_id, ip, user_agent, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
 `
 
 type InsertAccessLogParams struct {
-	UserID     int32     `db:"user_id"`
-	ActionType int8      `db:"action_type"`
-	LoginID    []byte    `db:"login_id"`
-	Ip         []byte    `db:"ip"`
-	UserAgent  string    `db:"user_agent"`
-	CreatedAt  time.Time `db:"created_at"`
+	UserID     sql.NullInt32  `db:"user_id"`
+	ActionType int8           `db:"action_type"`
+	Reason     int8           `db:"reason"`
+	LoginID    sql.NullString `db:"login_id"`
+	Ip         []byte         `db:"ip"`
+	UserAgent  string         `db:"user_agent"`
+	CreatedAt  time.Time      `db:"created_at"`
 }
 
 func (q *Queries) InsertAccessLog(ctx context.Context, arg InsertAccessLogParams) error {
 	_, err := q.db.ExecContext(ctx, insertAccessLog,
 		arg.UserID,
 		arg.ActionType,
+		arg.Reason,
 		arg.LoginID,
 		arg.Ip,
 		arg.UserAgent,
@@ -7,9 +7,30 @@ package queries
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
+const consumeRefreshToken = `-- name: ConsumeRefreshToken :execrows
+UPDATE users_refresh_tokens
+SET consumed_at = ?
+WHERE id = ?
+  AND consumed_at IS NULL
+`
+
+type ConsumeRefreshTokenParams struct {
+	ConsumedAt sql.NullTime `db:"consumed_at"`
+	ID         int64        `db:"id"`
+}
+
+func (q *Queries) ConsumeRefreshToken(ctx context.Context, arg ConsumeRefreshTokenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, consumeRefreshToken, arg.ConsumedAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteAccessTokensByLoginID = `-- name: DeleteAccessTokensByLoginID :exec
 DELETE
 FROM users_access_tokens
@@ -23,14 +44,33 @@ func (q *Queries) DeleteAccessTokensByLoginID(ctx context.Context, loginID []byt
 	return err
 }
 
+const deleteAccessTokensByUserID = `-- name: DeleteAccessTokensByUserID :exec
+DELETE
+FROM users_access_tokens
+WHERE users_access_tokens.refresh_token_id IN (SELECT users_refresh_tokens.id
+                                               FROM users_refresh_tokens
+                                               WHERE users_refresh_tokens.user_id = ?)
+`
+
+func (q *Queries) DeleteAccessTokensByUserID(ctx context.Context, userID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteAccessTokensByUserID, userID)
+	return err
+}
+
 const deleteExpiredAccessTokens = `-- name: DeleteExpiredAccessTokens :execrows
 DELETE
 FROM users_access_tokens
 WHERE created_at < ?
+LIMIT ?
 `
 
-func (q *Queries) DeleteExpiredAccessTokens(ctx context.Context, createdAt time.Time) (int64, error) {
-	result, err := q.db.ExecContext(ctx, deleteExpiredAccessTokens, createdAt)
+type DeleteExpiredAccessTokensParams struct {
+	CreatedAt time.Time `db:"created_at"`
+	Limit     int32     `db:"limit"`
+}
+
+func (q *Queries) DeleteExpiredAccessTokens(ctx context.Context, arg DeleteExpiredAccessTokensParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredAccessTokens, arg.CreatedAt, arg.Limit)
 	if err != nil {
 		return 0, err
 	}
@@ -41,10 +81,36 @@ const deleteExpiredRefreshTokens = `-- name: DeleteExpiredRefreshTokens :execrow
 DELETE
 FROM users_refresh_tokens
 WHERE created_at < ?
+LIMIT ?
 `
 
-func (q *Queries) DeleteExpiredRefreshTokens(ctx context.Context, createdAt time.Time) (int64, error) {
-	result, err := q.db.ExecContext(ctx, deleteExpiredRefreshTokens, createdAt)
+type DeleteExpiredRefreshTokensParams struct {
+	CreatedAt time.Time `db:"created_at"`
+	Limit     int32     `db:"limit"`
+}
+
+func (q *Queries) DeleteExpiredRefreshTokens(ctx context.Context, arg DeleteExpiredRefreshTokensParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredRefreshTokens, arg.CreatedAt, arg.Limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteExpiredUsedLoginStates = `-- name: DeleteExpiredUsedLoginStates :execrows
+DELETE
+FROM used_login_states
+WHERE expires_at < ?
+LIMIT ?
+`
+
+type DeleteExpiredUsedLoginStatesParams struct {
+	ExpiresAt time.Time `db:"expires_at"`
+	Limit     int32     `db:"limit"`
+}
+
+func (q *Queries) DeleteExpiredUsedLoginStates(ctx context.Context, arg DeleteExpiredUsedLoginStatesParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredUsedLoginStates, arg.ExpiresAt, arg.Limit)
 	if err != nil {
 		return 0, err
 	}
@@ -62,6 +128,103 @@ func (q *Queries) DeleteRefreshTokensByLoginID(ctx context.Context, loginID []by
 	return err
 }
 
+const deleteRefreshTokensByUserID = `-- name: DeleteRefreshTokensByUserID :exec
+DELETE
+FROM users_refresh_tokens
+WHERE user_id = ?
+`
+
+func (q *Queries) DeleteRefreshTokensByUserID(ctx context.Context, userID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteRefreshTokensByUserID, userID)
+	return err
+}
+
+const existsRefreshTokenByUserIDAndLoginID = `-- name: ExistsRefreshTokenByUserIDAndLoginID :one
+SELECT EXISTS(SELECT 1
+              FROM users_refresh_tokens
+              WHERE user_id = ?
+                AND login_id = ?) AS found
+`
+
+type ExistsRefreshTokenByUserIDAndLoginIDParams struct {
+	UserID  int32  `db:"user_id"`
+	LoginID []byte `db:"login_id"`
+}
+
+func (q *Queries) ExistsRefreshTokenByUserIDAndLoginID(ctx context.Context, arg ExistsRefreshTokenByUserIDAndLoginIDParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, existsRefreshTokenByUserIDAndLoginID, arg.UserID, arg.LoginID)
+	var found bool
+	err := row.Scan(&found)
+	return found, err
+}
+
+const getSessionsByUserID = `-- name: GetSessionsByUserID :many
+SELECT login_id,
+       CAST(MIN(created_at) AS DATETIME) AS logged_in_at,
+       CAST(MAX(created_at) AS DATETIME) AS last_refreshed_at
+FROM users_refresh_tokens
+WHERE user_id = ?
+  AND created_at >= ?
+GROUP BY login_id
+HAVING SUM(consumed_at IS NULL) > 0
+ORDER BY last_refreshed_at DESC
+`
+
+type GetSessionsByUserIDParams struct {
+	UserID    int32     `db:"user_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type GetSessionsByUserIDRow struct {
+	LoginID         []byte    `db:"login_id"`
+	LoggedInAt      time.Time `db:"logged_in_at"`
+	LastRefreshedAt time.Time `db:"last_refreshed_at"`
+}
+
+func (q *Queries) GetSessionsByUserID(ctx context.Context, arg GetSessionsByUserIDParams) ([]GetSessionsByUserIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSessionsByUserID, arg.UserID, arg.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSessionsByUserIDRow
+	for rows.Next() {
+		var i GetSessionsByUserIDRow
+		if err := rows.Scan(&i.LoginID, &i.LoggedInAt, &i.LastRefreshedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserByAccessTokenJTI = `-- name: GetUserByAccessTokenJTI :one
+SELECT users.id AS user_id, users.uuid AS user_uuid, users_refresh_tokens.login_id
+FROM users_access_tokens
+         JOIN users_refresh_tokens ON users_refresh_tokens.id = users_access_tokens.refresh_token_id
+         JOIN users ON users.id = users_refresh_tokens.user_id
+WHERE users_access_tokens.jti = ?
+`
+
+type GetUserByAccessTokenJTIRow struct {
+	UserID   int32  `db:"user_id"`
+	UserUuid []byte `db:"user_uuid"`
+	LoginID  []byte `db:"login_id"`
+}
+
+func (q *Queries) GetUserByAccessTokenJTI(ctx context.Context, jti []byte) (GetUserByAccessTokenJTIRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserByAccessTokenJTI, jti)
+	var i GetUserByAccessTokenJTIRow
+	err := row.Scan(&i.UserID, &i.UserUuid, &i.LoginID)
+	return i, err
+}
+
 const getUserIDAndRefreshTokenIDByJTI = `-- name: GetUserIDAndRefreshTokenIDByJTI :one
 SELECT id, user_id
 FROM users_refresh_tokens
@@ -80,21 +243,6 @@ func (q *Queries) GetUserIDAndRefreshTokenIDByJTI(ctx context.Context, jti []byt
 	return i, err
 }
 
-const getUserIDByAccessTokenJTI = `-- name: GetUserIDByAccessTokenJTI :one
-SELECT user_id
-FROM users_refresh_tokens
-WHERE id = (SELECT refresh_token_id
-            FROM users_access_tokens
-            WHERE users_access_tokens.jti = ?)
-`
-
-func (q *Queries) GetUserIDByAccessTokenJTI(ctx context.Context, jti []byte) (int32, error) {
-	row := q.db.QueryRowContext(ctx, getUserIDByAccessTokenJTI, jti)
-	var user_id int32
-	err := row.Scan(&user_id)
-	return user_id, err
-}
-
 const insertAccessToken = `-- name: InsertAccessToken :exec
 INSERT INTO users_access_tokens (refresh_token_id, jti, created_at)
 VALUES (?, ?, ?)
@@ -132,3 +280,21 @@ func (q *Queries) InsertRefreshToken(ctx context.Context, arg InsertRefreshToken
 	)
 	return err
 }
+
+const insertUsedLoginState = `-- name: InsertUsedLoginState :execrows
+INSERT IGNORE INTO used_login_states (jti, expires_at)
+VALUES (?, ?)
+`
+
+type InsertUsedLoginStateParams struct {
+	Jti       []byte    `db:"jti"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+func (q *Queries) InsertUsedLoginState(ctx context.Context, arg InsertUsedLoginStateParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, insertUsedLoginState, arg.Jti, arg.ExpiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
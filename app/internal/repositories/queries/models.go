@@ -5,25 +5,56 @@
 package queries
 
 import (
+	"database/sql"
 	"time"
 )
 
-type User struct {
+type RateLimitBucket struct {
+	BucketKey   string       `db:"bucket_key"`
+	Tokens      float64      `db:"tokens"`
+	UpdatedAt   time.Time    `db:"updated_at"`
+	LockedUntil sql.NullTime `db:"locked_until"`
+	ExpiresAt   time.Time    `db:"expires_at"`
+}
+
+type Role struct {
 	ID        int32     `db:"id"`
-	Uuid      []byte    `db:"uuid"`
+	Name      string    `db:"name"`
 	CreatedAt time.Time `db:"created_at"`
 }
 
-type UsersAccessLog struct {
-	ID         int64     `db:"id"`
-	UserID     int32     `db:"user_id"`
-	ActionType int8      `db:"action_type"`
-	LoginID    []byte    `db:"login_id"`
-	Ip         []byte    `db:"ip"`
-	UserAgent  string    `db:"user_agent"`
+type RolesPermission struct {
+	RoleID     int32     `db:"role_id"`
+	Permission string    `db:"permission"`
 	CreatedAt  time.Time `db:"created_at"`
 }
 
+type UsedLoginState struct {
+	Jti       []byte    `db:"jti"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+type User struct {
+	ID              int32        `db:"id"`
+	Uuid            []byte       `db:"uuid"`
+	CreatedAt       time.Time    `db:"created_at"`
+	Status          int8         `db:"status"`
+	SuspendedUntil  sql.NullTime `db:"suspended_until"`
+	StatusReason    string       `db:"status_reason"`
+	StatusUpdatedAt sql.NullTime `db:"status_updated_at"`
+}
+
+type UsersAccessLog struct {
+	ID         int64          `db:"id"`
+	UserID     sql.NullInt32  `db:"user_id"`
+	ActionType int8           `db:"action_type"`
+	Reason     int8           `db:"reason"`
+	LoginID    sql.NullString `db:"login_id"`
+	Ip         []byte         `db:"ip"`
+	UserAgent  string         `db:"user_agent"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
 type UsersAccessToken struct {
 	ID             int64     `db:"id"`
 	RefreshTokenID int64     `db:"refresh_token_id"`
@@ -38,15 +69,24 @@ type UsersLoginKey struct {
 }
 
 type UsersRefreshToken struct {
-	ID        int64     `db:"id"`
+	ID         int64        `db:"id"`
+	UserID     int32        `db:"user_id"`
+	Jti        []byte       `db:"jti"`
+	LoginID    []byte       `db:"login_id"`
+	CreatedAt  time.Time    `db:"created_at"`
+	ConsumedAt sql.NullTime `db:"consumed_at"`
+}
+
+type UsersRole struct {
 	UserID    int32     `db:"user_id"`
-	Jti       []byte    `db:"jti"`
-	LoginID   []byte    `db:"login_id"`
+	RoleID    int32     `db:"role_id"`
 	CreatedAt time.Time `db:"created_at"`
 }
 
 type UsersSub struct {
+	ID        int32     `db:"id"`
 	UserID    int32     `db:"user_id"`
+	Provider  string    `db:"provider"`
 	Sub       string    `db:"sub"`
 	CreatedAt time.Time `db:"created_at"`
 }
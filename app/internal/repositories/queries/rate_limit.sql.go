@@ -0,0 +1,105 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: rate_limit.sql
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const deleteExpiredRateLimitBuckets = `-- name: DeleteExpiredRateLimitBuckets :execrows
+DELETE
+FROM rate_limit_buckets
+WHERE expires_at < ?
+LIMIT ?
+`
+
+type DeleteExpiredRateLimitBucketsParams struct {
+	ExpiresAt time.Time `db:"expires_at"`
+	Limit     int32     `db:"limit"`
+}
+
+func (q *Queries) DeleteExpiredRateLimitBuckets(ctx context.Context, arg DeleteExpiredRateLimitBucketsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredRateLimitBuckets, arg.ExpiresAt, arg.Limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getRateLimitBucketForUpdate = `-- name: GetRateLimitBucketForUpdate :one
+SELECT tokens, updated_at, locked_until
+FROM rate_limit_buckets
+WHERE bucket_key = ?
+FOR UPDATE
+`
+
+type GetRateLimitBucketForUpdateRow struct {
+	Tokens      float64      `db:"tokens"`
+	UpdatedAt   time.Time    `db:"updated_at"`
+	LockedUntil sql.NullTime `db:"locked_until"`
+}
+
+func (q *Queries) GetRateLimitBucketForUpdate(ctx context.Context, bucketKey string) (GetRateLimitBucketForUpdateRow, error) {
+	row := q.db.QueryRowContext(ctx, getRateLimitBucketForUpdate, bucketKey)
+	var i GetRateLimitBucketForUpdateRow
+	err := row.Scan(&i.Tokens, &i.UpdatedAt, &i.LockedUntil)
+	return i, err
+}
+
+const insertRateLimitBucketIfNotExists = `-- name: InsertRateLimitBucketIfNotExists :exec
+INSERT INTO rate_limit_buckets (bucket_key, tokens, updated_at, locked_until, expires_at)
+VALUES (?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE bucket_key = bucket_key
+`
+
+type InsertRateLimitBucketIfNotExistsParams struct {
+	BucketKey   string       `db:"bucket_key"`
+	Tokens      float64      `db:"tokens"`
+	UpdatedAt   time.Time    `db:"updated_at"`
+	LockedUntil sql.NullTime `db:"locked_until"`
+	ExpiresAt   time.Time    `db:"expires_at"`
+}
+
+func (q *Queries) InsertRateLimitBucketIfNotExists(ctx context.Context, arg InsertRateLimitBucketIfNotExistsParams) error {
+	_, err := q.db.ExecContext(ctx, insertRateLimitBucketIfNotExists,
+		arg.BucketKey,
+		arg.Tokens,
+		arg.UpdatedAt,
+		arg.LockedUntil,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const updateRateLimitBucket = `-- name: UpdateRateLimitBucket :exec
+UPDATE rate_limit_buckets
+SET tokens       = ?,
+    updated_at   = ?,
+    locked_until = ?,
+    expires_at   = ?
+WHERE bucket_key = ?
+`
+
+type UpdateRateLimitBucketParams struct {
+	Tokens      float64      `db:"tokens"`
+	UpdatedAt   time.Time    `db:"updated_at"`
+	LockedUntil sql.NullTime `db:"locked_until"`
+	ExpiresAt   time.Time    `db:"expires_at"`
+	BucketKey   string       `db:"bucket_key"`
+}
+
+func (q *Queries) UpdateRateLimitBucket(ctx context.Context, arg UpdateRateLimitBucketParams) error {
+	_, err := q.db.ExecContext(ctx, updateRateLimitBucket,
+		arg.Tokens,
+		arg.UpdatedAt,
+		arg.LockedUntil,
+		arg.ExpiresAt,
+		arg.BucketKey,
+	)
+	return err
+}
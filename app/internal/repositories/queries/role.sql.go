@@ -0,0 +1,237 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: role.sql
+
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const deletePermissionFromRole = `-- name: DeletePermissionFromRole :execrows
+DELETE
+FROM roles_permissions
+WHERE role_id = ?
+  AND permission = ?
+`
+
+type DeletePermissionFromRoleParams struct {
+	RoleID     int32  `db:"role_id"`
+	Permission string `db:"permission"`
+}
+
+func (q *Queries) DeletePermissionFromRole(ctx context.Context, arg DeletePermissionFromRoleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deletePermissionFromRole, arg.RoleID, arg.Permission)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteRole = `-- name: DeleteRole :execrows
+DELETE
+FROM roles
+WHERE id = ?
+`
+
+func (q *Queries) DeleteRole(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteRole, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteRoleFromUserID = `-- name: DeleteRoleFromUserID :execrows
+DELETE
+FROM users_roles
+WHERE user_id = ?
+  AND role_id = ?
+`
+
+type DeleteRoleFromUserIDParams struct {
+	UserID int32 `db:"user_id"`
+	RoleID int32 `db:"role_id"`
+}
+
+func (q *Queries) DeleteRoleFromUserID(ctx context.Context, arg DeleteRoleFromUserIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteRoleFromUserID, arg.UserID, arg.RoleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getPermissionsByUserID = `-- name: GetPermissionsByUserID :many
+SELECT DISTINCT roles_permissions.permission
+FROM users_roles
+         INNER JOIN roles_permissions ON users_roles.role_id = roles_permissions.role_id
+WHERE users_roles.user_id = ?
+ORDER BY roles_permissions.permission
+`
+
+func (q *Queries) GetPermissionsByUserID(ctx context.Context, userID int32) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getPermissionsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			return nil, err
+		}
+		items = append(items, permission)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRoleIDByName = `-- name: GetRoleIDByName :one
+SELECT id
+FROM roles
+WHERE name = ?
+`
+
+func (q *Queries) GetRoleIDByName(ctx context.Context, name string) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getRoleIDByName, name)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getRoleNamesByUserID = `-- name: GetRoleNamesByUserID :many
+SELECT roles.name
+FROM users_roles
+         INNER JOIN roles ON users_roles.role_id = roles.id
+WHERE users_roles.user_id = ?
+ORDER BY roles.name
+`
+
+func (q *Queries) GetRoleNamesByUserID(ctx context.Context, userID int32) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getRoleNamesByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRolesWithPermissions = `-- name: GetRolesWithPermissions :many
+SELECT roles.id, roles.name, roles.created_at, roles_permissions.permission
+FROM roles
+         LEFT JOIN roles_permissions ON roles.id = roles_permissions.role_id
+ORDER BY roles.name, roles_permissions.permission
+`
+
+type GetRolesWithPermissionsRow struct {
+	ID         int32          `db:"id"`
+	Name       string         `db:"name"`
+	CreatedAt  time.Time      `db:"created_at"`
+	Permission sql.NullString `db:"permission"`
+}
+
+func (q *Queries) GetRolesWithPermissions(ctx context.Context) ([]GetRolesWithPermissionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getRolesWithPermissions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRolesWithPermissionsRow
+	for rows.Next() {
+		var i GetRolesWithPermissionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.Permission,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertPermissionForRole = `-- name: InsertPermissionForRole :exec
+INSERT IGNORE INTO roles_permissions (role_id, permission, created_at)
+VALUES (?, ?, ?)
+`
+
+type InsertPermissionForRoleParams struct {
+	RoleID     int32     `db:"role_id"`
+	Permission string    `db:"permission"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func (q *Queries) InsertPermissionForRole(ctx context.Context, arg InsertPermissionForRoleParams) error {
+	_, err := q.db.ExecContext(ctx, insertPermissionForRole, arg.RoleID, arg.Permission, arg.CreatedAt)
+	return err
+}
+
+const insertRoleForUserID = `-- name: InsertRoleForUserID :execrows
+INSERT IGNORE INTO users_roles (user_id, role_id, created_at)
+VALUES (?, ?, ?)
+`
+
+type InsertRoleForUserIDParams struct {
+	UserID    int32     `db:"user_id"`
+	RoleID    int32     `db:"role_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (q *Queries) InsertRoleForUserID(ctx context.Context, arg InsertRoleForUserIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, insertRoleForUserID, arg.UserID, arg.RoleID, arg.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const upsertRoleByName = `-- name: UpsertRoleByName :execlastid
+INSERT INTO roles (name, created_at)
+VALUES (?, ?)
+ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id)
+`
+
+type UpsertRoleByNameParams struct {
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (q *Queries) UpsertRoleByName(ctx context.Context, arg UpsertRoleByNameParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, upsertRoleByName, arg.Name, arg.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
@@ -7,60 +7,266 @@ package queries
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
-const deleteLoginKey = `-- name: DeleteLoginKey :exec
+const deleteExpiredLoginKeys = `-- name: DeleteExpiredLoginKeys :execrows
 DELETE
 FROM users_login_key
-WHERE user_id = ?
+WHERE created_at < ?
+LIMIT ?
 `
 
-func (q *Queries) DeleteLoginKey(ctx context.Context, userID int32) error {
-	_, err := q.db.ExecContext(ctx, deleteLoginKey, userID)
-	return err
+type DeleteExpiredLoginKeysParams struct {
+	CreatedAt time.Time `db:"created_at"`
+	Limit     int32     `db:"limit"`
+}
+
+func (q *Queries) DeleteExpiredLoginKeys(ctx context.Context, arg DeleteExpiredLoginKeysParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredLoginKeys, arg.CreatedAt, arg.Limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteLoginKey = `-- name: DeleteLoginKey :execrows
+DELETE
+FROM users_login_key
+WHERE login_key = ?
+`
+
+func (q *Queries) DeleteLoginKey(ctx context.Context, loginKey int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteLoginKey, loginKey)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const deleteUserSubBySub = `-- name: DeleteUserSubBySub :exec
 DELETE
 FROM users_sub
-WHERE sub = ?
+WHERE provider = ?
+  AND sub = ?
 `
 
-func (q *Queries) DeleteUserSubBySub(ctx context.Context, sub string) error {
-	_, err := q.db.ExecContext(ctx, deleteUserSubBySub, sub)
+type DeleteUserSubBySubParams struct {
+	Provider string `db:"provider"`
+	Sub      string `db:"sub"`
+}
+
+func (q *Queries) DeleteUserSubBySub(ctx context.Context, arg DeleteUserSubBySubParams) error {
+	_, err := q.db.ExecContext(ctx, deleteUserSubBySub, arg.Provider, arg.Sub)
 	return err
 }
 
+const deleteUserSubByUserID = `-- name: DeleteUserSubByUserID :execrows
+DELETE
+FROM users_sub
+WHERE user_id = ?
+  AND provider = ?
+  AND sub = ?
+`
+
+type DeleteUserSubByUserIDParams struct {
+	UserID   int32  `db:"user_id"`
+	Provider string `db:"provider"`
+	Sub      string `db:"sub"`
+}
+
+func (q *Queries) DeleteUserSubByUserID(ctx context.Context, arg DeleteUserSubByUserIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteUserSubByUserID, arg.UserID, arg.Provider, arg.Sub)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getUserByID = `-- name: GetUserByID :one
+SELECT uuid, created_at, status, suspended_until, status_reason
+FROM users
+WHERE id = ?
+`
+
+type GetUserByIDRow struct {
+	Uuid           []byte       `db:"uuid"`
+	CreatedAt      time.Time    `db:"created_at"`
+	Status         int8         `db:"status"`
+	SuspendedUntil sql.NullTime `db:"suspended_until"`
+	StatusReason   string       `db:"status_reason"`
+}
+
+func (q *Queries) GetUserByID(ctx context.Context, id int32) (GetUserByIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserByID, id)
+	var i GetUserByIDRow
+	err := row.Scan(
+		&i.Uuid,
+		&i.CreatedAt,
+		&i.Status,
+		&i.SuspendedUntil,
+		&i.StatusReason,
+	)
+	return i, err
+}
+
+const getUserByIDForUpdate = `-- name: GetUserByIDForUpdate :one
+SELECT uuid, created_at, status, suspended_until, status_reason
+FROM users
+WHERE id = ?
+FOR UPDATE
+`
+
+type GetUserByIDForUpdateRow struct {
+	Uuid           []byte       `db:"uuid"`
+	CreatedAt      time.Time    `db:"created_at"`
+	Status         int8         `db:"status"`
+	SuspendedUntil sql.NullTime `db:"suspended_until"`
+	StatusReason   string       `db:"status_reason"`
+}
+
+func (q *Queries) GetUserByIDForUpdate(ctx context.Context, id int32) (GetUserByIDForUpdateRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserByIDForUpdate, id)
+	var i GetUserByIDForUpdateRow
+	err := row.Scan(
+		&i.Uuid,
+		&i.CreatedAt,
+		&i.Status,
+		&i.SuspendedUntil,
+		&i.StatusReason,
+	)
+	return i, err
+}
+
 const getUserIDByLoginKey = `-- name: GetUserIDByLoginKey :one
-SELECT user_id
+SELECT user_id, created_at
 FROM users_login_key
 WHERE login_key = ?
 `
 
-func (q *Queries) GetUserIDByLoginKey(ctx context.Context, loginKey int64) (int32, error) {
+type GetUserIDByLoginKeyRow struct {
+	UserID    int32     `db:"user_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (q *Queries) GetUserIDByLoginKey(ctx context.Context, loginKey int64) (GetUserIDByLoginKeyRow, error) {
 	row := q.db.QueryRowContext(ctx, getUserIDByLoginKey, loginKey)
-	var user_id int32
-	err := row.Scan(&user_id)
-	return user_id, err
+	var i GetUserIDByLoginKeyRow
+	err := row.Scan(&i.UserID, &i.CreatedAt)
+	return i, err
 }
 
 const getUserIDBySub = `-- name: GetUserIDBySub :one
 SELECT user_id
 FROM users_sub
-WHERE sub = ?
+WHERE provider = ?
+  AND sub = ?
 `
 
-func (q *Queries) GetUserIDBySub(ctx context.Context, sub string) (int32, error) {
-	row := q.db.QueryRowContext(ctx, getUserIDBySub, sub)
+type GetUserIDBySubParams struct {
+	Provider string `db:"provider"`
+	Sub      string `db:"sub"`
+}
+
+func (q *Queries) GetUserIDBySub(ctx context.Context, arg GetUserIDBySubParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getUserIDBySub, arg.Provider, arg.Sub)
 	var user_id int32
 	err := row.Scan(&user_id)
 	return user_id, err
 }
 
+const getUserIDByUUID = `-- name: GetUserIDByUUID :one
+SELECT id
+FROM users
+WHERE uuid = ?
+`
+
+func (q *Queries) GetUserIDByUUID(ctx context.Context, uuid []byte) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getUserIDByUUID, uuid)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getUserSubsByUserID = `-- name: GetUserSubsByUserID :many
+SELECT provider, sub, created_at
+FROM users_sub
+WHERE user_id = ?
+ORDER BY id
+`
+
+type GetUserSubsByUserIDRow struct {
+	Provider  string    `db:"provider"`
+	Sub       string    `db:"sub"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (q *Queries) GetUserSubsByUserID(ctx context.Context, userID int32) ([]GetUserSubsByUserIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserSubsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserSubsByUserIDRow
+	for rows.Next() {
+		var i GetUserSubsByUserIDRow
+		if err := rows.Scan(&i.Provider, &i.Sub, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserSubsByUserIDForUpdate = `-- name: GetUserSubsByUserIDForUpdate :many
+SELECT provider, sub, created_at
+FROM users_sub
+WHERE user_id = ?
+ORDER BY id
+FOR UPDATE
+`
+
+type GetUserSubsByUserIDForUpdateRow struct {
+	Provider  string    `db:"provider"`
+	Sub       string    `db:"sub"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (q *Queries) GetUserSubsByUserIDForUpdate(ctx context.Context, userID int32) ([]GetUserSubsByUserIDForUpdateRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserSubsByUserIDForUpdate, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserSubsByUserIDForUpdateRow
+	for rows.Next() {
+		var i GetUserSubsByUserIDForUpdateRow
+		if err := rows.Scan(&i.Provider, &i.Sub, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const insertLoginKeyForUserID = `-- name: InsertLoginKeyForUserID :exec
 INSERT INTO users_login_key (user_id, login_key, created_at)
 VALUES (?, ?, ?)
+ON DUPLICATE KEY UPDATE login_key  = VALUES(login_key),
+                        created_at = VALUES(created_at)
 `
 
 type InsertLoginKeyForUserIDParams struct {
@@ -75,20 +281,76 @@ func (q *Queries) InsertLoginKeyForUserID(ctx context.Context, arg InsertLoginKe
 }
 
 const insertSubForUserID = `-- name: InsertSubForUserID :execrows
-INSERT IGNORE INTO users_sub (user_id, sub, created_at)
-VALUES (?, ?, ?)
+INSERT IGNORE INTO users_sub (user_id, provider, sub, created_at)
+VALUES (?, ?, ?, ?)
 `
 
 type InsertSubForUserIDParams struct {
 	UserID    int32     `db:"user_id"`
+	Provider  string    `db:"provider"`
 	Sub       string    `db:"sub"`
 	CreatedAt time.Time `db:"created_at"`
 }
 
 func (q *Queries) InsertSubForUserID(ctx context.Context, arg InsertSubForUserIDParams) (int64, error) {
-	result, err := q.db.ExecContext(ctx, insertSubForUserID, arg.UserID, arg.Sub, arg.CreatedAt)
+	result, err := q.db.ExecContext(ctx, insertSubForUserID,
+		arg.UserID,
+		arg.Provider,
+		arg.Sub,
+		arg.CreatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateUserStatus = `-- name: UpdateUserStatus :execrows
+UPDATE users
+SET status            = ?,
+    suspended_until   = ?,
+    status_reason     = ?,
+    status_updated_at = ?
+WHERE id = ?
+`
+
+type UpdateUserStatusParams struct {
+	Status          int8         `db:"status"`
+	SuspendedUntil  sql.NullTime `db:"suspended_until"`
+	StatusReason    string       `db:"status_reason"`
+	StatusUpdatedAt sql.NullTime `db:"status_updated_at"`
+	ID              int32        `db:"id"`
+}
+
+func (q *Queries) UpdateUserStatus(ctx context.Context, arg UpdateUserStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateUserStatus,
+		arg.Status,
+		arg.SuspendedUntil,
+		arg.StatusReason,
+		arg.StatusUpdatedAt,
+		arg.ID,
+	)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected()
 }
+
+const upsertUserByUUID = `-- name: UpsertUserByUUID :execlastid
+INSERT INTO users (uuid, created_at)
+VALUES (?, ?)
+ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id)
+`
+
+type UpsertUserByUUIDParams struct {
+	Uuid      []byte    `db:"uuid"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (q *Queries) UpsertUserByUUID(ctx context.Context, arg UpsertUserByUUIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, upsertUserByUUID, arg.Uuid, arg.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
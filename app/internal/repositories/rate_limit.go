@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/okocraft/auth-service/internal/domain"
+	"github.com/okocraft/auth-service/internal/repositories/database"
+	"github.com/okocraft/auth-service/internal/repositories/queries"
+)
+
+type RateLimitRepository interface {
+	// GetOrCreateRateLimitBucketForUpdate locks the bucket of the key, inserting initial if the bucket does not exist.
+	GetOrCreateRateLimitBucketForUpdate(ctx context.Context, conn database.Connection, key string, initial domain.RateLimitBucket, expiresAt time.Time) (domain.RateLimitBucket, error)
+	SaveRateLimitBucket(ctx context.Context, conn database.Connection, key string, bucket domain.RateLimitBucket, expiresAt time.Time) error
+	DeleteExpiredRateLimitBuckets(ctx context.Context, conn database.Connection, now time.Time, limit int32) (int64, error)
+}
+
+func NewRateLimitRepository() RateLimitRepository {
+	return &rateLimitRepository{}
+}
+
+type rateLimitRepository struct{}
+
+func (r rateLimitRepository) GetOrCreateRateLimitBucketForUpdate(ctx context.Context, conn database.Connection, key string, initial domain.RateLimitBucket, expiresAt time.Time) (domain.RateLimitBucket, error) {
+	q := conn.Queries()
+
+	// inserts the row first so that SELECT ... FOR UPDATE always locks an existing row instead of a gap.
+	// ON DUPLICATE KEY UPDATE takes the exclusive lock on an existing row, while INSERT IGNORE would take a shared lock
+	// and two concurrent requests for the same bucket could deadlock when upgrading it.
+	err := q.InsertRateLimitBucketIfNotExists(ctx, queries.InsertRateLimitBucketIfNotExistsParams{
+		BucketKey:   key,
+		Tokens:      initial.Tokens,
+		UpdatedAt:   initial.UpdatedAt,
+		LockedUntil: toNullTime(initial.LockedUntil),
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return domain.RateLimitBucket{}, database.NewDBErrorWithStackTrace(err)
+	}
+
+	row, err := q.GetRateLimitBucketForUpdate(ctx, key)
+	if err != nil {
+		return domain.RateLimitBucket{}, database.NewDBErrorWithStackTrace(err)
+	}
+
+	return domain.RateLimitBucket{
+		Tokens:      row.Tokens,
+		UpdatedAt:   row.UpdatedAt,
+		LockedUntil: row.LockedUntil.Time,
+	}, nil
+}
+
+func (r rateLimitRepository) SaveRateLimitBucket(ctx context.Context, conn database.Connection, key string, bucket domain.RateLimitBucket, expiresAt time.Time) error {
+	err := conn.Queries().UpdateRateLimitBucket(ctx, queries.UpdateRateLimitBucketParams{
+		Tokens:      bucket.Tokens,
+		UpdatedAt:   bucket.UpdatedAt,
+		LockedUntil: toNullTime(bucket.LockedUntil),
+		ExpiresAt:   expiresAt,
+		BucketKey:   key,
+	})
+	if err != nil {
+		return database.NewDBErrorWithStackTrace(err)
+	}
+	return nil
+}
+
+func (r rateLimitRepository) DeleteExpiredRateLimitBuckets(ctx context.Context, conn database.Connection, now time.Time, limit int32) (int64, error) {
+	rows, err := conn.Queries().DeleteExpiredRateLimitBuckets(ctx, queries.DeleteExpiredRateLimitBucketsParams{
+		ExpiresAt: now,
+		Limit:     limit,
+	})
+	if err != nil {
+		return 0, database.NewDBErrorWithStackTrace(err)
+	}
+	return rows, nil
+}
+
+func toNullTime(t time.Time) sql.NullTime {
+	if t.IsZero() {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t, Valid: true}
+}
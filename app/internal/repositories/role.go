@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/okocraft/auth-service/internal/domain"
+	"github.com/okocraft/auth-service/internal/repositories/database"
+	"github.com/okocraft/auth-service/internal/repositories/queries"
+	"github.com/okocraft/authlib/user"
+)
+
+type RoleRepository interface {
+	UpsertRoleByName(ctx context.Context, conn database.Connection, name string, now time.Time) (domain.RoleID, error)
+	GetRoleIDByName(ctx context.Context, conn database.Connection, name string) (domain.RoleID, error)
+	GetRoles(ctx context.Context, conn database.Connection) ([]domain.Role, error)
+	DeleteRole(ctx context.Context, conn database.Connection, roleID domain.RoleID) error
+	SavePermissionForRole(ctx context.Context, conn database.Connection, roleID domain.RoleID, permission string, now time.Time) error
+	DeletePermissionFromRole(ctx context.Context, conn database.Connection, roleID domain.RoleID, permission string) error
+	SaveRoleForUserID(ctx context.Context, conn database.Connection, userID user.ID, roleID domain.RoleID, now time.Time) error
+	DeleteRoleFromUserID(ctx context.Context, conn database.Connection, userID user.ID, roleID domain.RoleID) error
+	GetAuthorizationByUserID(ctx context.Context, conn database.Connection, userID user.ID) (domain.Authorization, error)
+}
+
+func NewRoleRepository() RoleRepository {
+	return &roleRepository{}
+}
+
+type roleRepository struct{}
+
+func (r roleRepository) UpsertRoleByName(ctx context.Context, conn database.Connection, name string, now time.Time) (domain.RoleID, error) {
+	id, err := conn.Queries().UpsertRoleByName(ctx, queries.UpsertRoleByNameParams{
+		Name:      name,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return 0, database.NewDBErrorWithStackTrace(err)
+	}
+	return domain.RoleID(id), nil
+}
+
+func (r roleRepository) GetRoleIDByName(ctx context.Context, conn database.Connection, name string) (domain.RoleID, error) {
+	id, err := conn.Queries().GetRoleIDByName(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, domain.RoleNotFoundError
+	} else if err != nil {
+		return 0, database.NewDBErrorWithStackTrace(err)
+	}
+	return domain.RoleID(id), nil
+}
+
+func (r roleRepository) GetRoles(ctx context.Context, conn database.Connection) ([]domain.Role, error) {
+	rows, err := conn.Queries().GetRolesWithPermissions(ctx)
+	if err != nil {
+		return nil, database.NewDBErrorWithStackTrace(err)
+	}
+
+	roles := make([]domain.Role, 0, len(rows))
+	for _, row := range rows {
+		// the rows are ordered by the role name, so the permissions of the same role are contiguous
+		if len(roles) == 0 || roles[len(roles)-1].ID != domain.RoleID(row.ID) {
+			roles = append(roles, domain.Role{
+				ID:          domain.RoleID(row.ID),
+				Name:        row.Name,
+				Permissions: []string{},
+				CreatedAt:   row.CreatedAt,
+			})
+		}
+
+		if row.Permission.Valid {
+			last := &roles[len(roles)-1]
+			last.Permissions = append(last.Permissions, row.Permission.String)
+		}
+	}
+	return roles, nil
+}
+
+func (r roleRepository) DeleteRole(ctx context.Context, conn database.Connection, roleID domain.RoleID) error {
+	rows, err := conn.Queries().DeleteRole(ctx, int32(roleID))
+	if err != nil {
+		return database.NewDBErrorWithStackTrace(err)
+	} else if rows == 0 {
+		return domain.RoleNotFoundError
+	}
+	return nil
+}
+
+func (r roleRepository) SavePermissionForRole(ctx context.Context, conn database.Connection, roleID domain.RoleID, permission string, now time.Time) error {
+	err := conn.Queries().InsertPermissionForRole(ctx, queries.InsertPermissionForRoleParams{
+		RoleID:     int32(roleID),
+		Permission: permission,
+		CreatedAt:  now,
+	})
+	if err != nil {
+		return database.NewDBErrorWithStackTrace(err)
+	}
+	return nil
+}
+
+func (r roleRepository) DeletePermissionFromRole(ctx context.Context, conn database.Connection, roleID domain.RoleID, permission string) error {
+	rows, err := conn.Queries().DeletePermissionFromRole(ctx, queries.DeletePermissionFromRoleParams{
+		RoleID:     int32(roleID),
+		Permission: permission,
+	})
+	if err != nil {
+		return database.NewDBErrorWithStackTrace(err)
+	} else if rows == 0 {
+		return domain.PermissionNotFoundError
+	}
+	return nil
+}
+
+func (r roleRepository) SaveRoleForUserID(ctx context.Context, conn database.Connection, userID user.ID, roleID domain.RoleID, now time.Time) error {
+	rows, err := conn.Queries().InsertRoleForUserID(ctx, queries.InsertRoleForUserIDParams{
+		UserID:    int32(userID),
+		RoleID:    int32(roleID),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return database.NewDBErrorWithStackTrace(err)
+	} else if rows == 0 {
+		return domain.RoleAlreadyAssignedError
+	}
+	return nil
+}
+
+func (r roleRepository) DeleteRoleFromUserID(ctx context.Context, conn database.Connection, userID user.ID, roleID domain.RoleID) error {
+	rows, err := conn.Queries().DeleteRoleFromUserID(ctx, queries.DeleteRoleFromUserIDParams{
+		UserID: int32(userID),
+		RoleID: int32(roleID),
+	})
+	if err != nil {
+		return database.NewDBErrorWithStackTrace(err)
+	} else if rows == 0 {
+		return domain.RoleNotAssignedError
+	}
+	return nil
+}
+
+func (r roleRepository) GetAuthorizationByUserID(ctx context.Context, conn database.Connection, userID user.ID) (domain.Authorization, error) {
+	roles, err := conn.Queries().GetRoleNamesByUserID(ctx, int32(userID))
+	if err != nil {
+		return domain.Authorization{}, database.NewDBErrorWithStackTrace(err)
+	}
+
+	permissions, err := conn.Queries().GetPermissionsByUserID(ctx, int32(userID))
+	if err != nil {
+		return domain.Authorization{}, database.NewDBErrorWithStackTrace(err)
+	}
+
+	return domain.Authorization{
+		Roles:       roles,
+		Permissions: permissions,
+	}, nil
+}
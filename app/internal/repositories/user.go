@@ -6,6 +6,8 @@ import (
 	"errors"
 	"time"
 
+	"github.com/Siroshun09/serrors"
+	"github.com/gofrs/uuid/v5"
 	"github.com/okocraft/auth-service/internal/domain"
 	"github.com/okocraft/auth-service/internal/repositories/database"
 	"github.com/okocraft/auth-service/internal/repositories/queries"
@@ -13,11 +15,21 @@ import (
 )
 
 type UserRepository interface {
-	GetUserIDBySub(ctx context.Context, conn database.Connection, sub string) (user.ID, error)
-	GetUserIDByLoginKey(ctx context.Context, conn database.Connection, loginKey domain.LoginKey) (user.ID, error)
+	UpsertUserByUUID(ctx context.Context, conn database.Connection, userUUID uuid.UUID, now time.Time) (user.ID, error)
+	GetUserIDByUUID(ctx context.Context, conn database.Connection, userUUID uuid.UUID) (user.ID, error)
+	GetUserByID(ctx context.Context, conn database.Connection, id user.ID) (domain.User, error)
+	GetUserByIDForUpdate(ctx context.Context, conn database.Connection, id user.ID) (domain.User, error)
+	UpdateUserStatus(ctx context.Context, conn database.Connection, id user.ID, status domain.UserStatus, now time.Time) error
+	GetUserIDBySub(ctx context.Context, conn database.Connection, provider string, sub string) (user.ID, error)
+	GetUserIDByLoginKey(ctx context.Context, conn database.Connection, loginKey domain.LoginKey) (user.ID, time.Time, error)
 	SaveLoginKeyForUserID(ctx context.Context, conn database.Connection, id user.ID, loginKey domain.LoginKey, now time.Time) error
-	DeleteLoginKeyByUserID(ctx context.Context, conn database.Connection, id user.ID) error
-	SaveUserSub(ctx context.Context, conn database.Connection, userID user.ID, sub string, now time.Time) error
+	// DeleteLoginKey deletes the login key, or returns domain.UserNotFoundByLoginKeyError if it is already used or reissued.
+	DeleteLoginKey(ctx context.Context, conn database.Connection, loginKey domain.LoginKey) error
+	DeleteExpiredLoginKeys(ctx context.Context, conn database.Connection, expiredAt time.Time, limit int32) (int64, error)
+	SaveUserSub(ctx context.Context, conn database.Connection, userID user.ID, provider string, sub string, now time.Time) error
+	GetIdentitiesByUserID(ctx context.Context, conn database.Connection, userID user.ID) ([]domain.Identity, error)
+	GetIdentitiesByUserIDForUpdate(ctx context.Context, conn database.Connection, userID user.ID) ([]domain.Identity, error)
+	DeleteUserSub(ctx context.Context, conn database.Connection, userID user.ID, provider string, sub string) error
 }
 
 func NewUserRepository() UserRepository {
@@ -26,21 +38,90 @@ func NewUserRepository() UserRepository {
 
 type userRepository struct{}
 
-func (r userRepository) GetUserIDBySub(ctx context.Context, conn database.Connection, sub string) (user.ID, error) {
-	id, err := conn.Queries().GetUserIDBySub(ctx, sub)
+func (r userRepository) UpsertUserByUUID(ctx context.Context, conn database.Connection, userUUID uuid.UUID, now time.Time) (user.ID, error) {
+	id, err := conn.Queries().UpsertUserByUUID(ctx, queries.UpsertUserByUUIDParams{
+		Uuid:      userUUID.Bytes(),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return 0, database.NewDBErrorWithStackTrace(err)
+	}
+	return user.ID(id), nil
+}
+
+func (r userRepository) GetUserIDByUUID(ctx context.Context, conn database.Connection, userUUID uuid.UUID) (user.ID, error) {
+	id, err := conn.Queries().GetUserIDByUUID(ctx, userUUID.Bytes())
 	if errors.Is(err, sql.ErrNoRows) {
-		return 0, domain.UserNotFoundBySubError
+		return 0, domain.UserNotFoundError
 	} else if err != nil {
 		return 0, database.NewDBErrorWithStackTrace(err)
 	}
-
 	return user.ID(id), nil
 }
 
-func (r userRepository) GetUserIDByLoginKey(ctx context.Context, conn database.Connection, loginKey domain.LoginKey) (user.ID, error) {
-	id, err := conn.Queries().GetUserIDByLoginKey(ctx, int64(loginKey))
+func (r userRepository) GetUserByID(ctx context.Context, conn database.Connection, id user.ID) (domain.User, error) {
+	row, err := conn.Queries().GetUserByID(ctx, int32(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.User{}, domain.UserNotFoundError
+	} else if err != nil {
+		return domain.User{}, database.NewDBErrorWithStackTrace(err)
+	}
+
+	return toDomainUser(id, queries.GetUserByIDForUpdateRow(row))
+}
+
+func (r userRepository) GetUserByIDForUpdate(ctx context.Context, conn database.Connection, id user.ID) (domain.User, error) {
+	row, err := conn.Queries().GetUserByIDForUpdate(ctx, int32(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.User{}, domain.UserNotFoundError
+	} else if err != nil {
+		return domain.User{}, database.NewDBErrorWithStackTrace(err)
+	}
+
+	return toDomainUser(id, row)
+}
+
+func toDomainUser(id user.ID, row queries.GetUserByIDForUpdateRow) (domain.User, error) {
+	userUUID, err := uuid.FromBytes(row.Uuid)
+	if err != nil {
+		return domain.User{}, serrors.WithStackTrace(err)
+	}
+
+	return domain.User{
+		ID:        id,
+		UUID:      userUUID,
+		CreatedAt: row.CreatedAt,
+		Status: domain.UserStatus{
+			Type:           domain.UserStatusType(row.Status),
+			SuspendedUntil: row.SuspendedUntil.Time,
+			Reason:         row.StatusReason,
+		},
+	}, nil
+}
+
+func (r userRepository) UpdateUserStatus(ctx context.Context, conn database.Connection, id user.ID, status domain.UserStatus, now time.Time) error {
+	rows, err := conn.Queries().UpdateUserStatus(ctx, queries.UpdateUserStatusParams{
+		Status:          int8(status.Type),
+		SuspendedUntil:  sql.NullTime{Time: status.SuspendedUntil, Valid: status.Type == domain.UserStatusTypeSuspended},
+		StatusReason:    status.Reason,
+		StatusUpdatedAt: sql.NullTime{Time: now, Valid: true},
+		ID:              int32(id),
+	})
+	if err != nil {
+		return database.NewDBErrorWithStackTrace(err)
+	} else if rows == 0 {
+		return domain.UserNotFoundError
+	}
+	return nil
+}
+
+func (r userRepository) GetUserIDBySub(ctx context.Context, conn database.Connection, provider string, sub string) (user.ID, error) {
+	id, err := conn.Queries().GetUserIDBySub(ctx, queries.GetUserIDBySubParams{
+		Provider: provider,
+		Sub:      sub,
+	})
 	if errors.Is(err, sql.ErrNoRows) {
-		return 0, domain.UserNotFoundByLoginKeyError
+		return 0, domain.UserNotFoundBySubError
 	} else if err != nil {
 		return 0, database.NewDBErrorWithStackTrace(err)
 	}
@@ -48,6 +129,17 @@ func (r userRepository) GetUserIDByLoginKey(ctx context.Context, conn database.C
 	return user.ID(id), nil
 }
 
+func (r userRepository) GetUserIDByLoginKey(ctx context.Context, conn database.Connection, loginKey domain.LoginKey) (user.ID, time.Time, error) {
+	row, err := conn.Queries().GetUserIDByLoginKey(ctx, int64(loginKey))
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, time.Time{}, domain.UserNotFoundByLoginKeyError
+	} else if err != nil {
+		return 0, time.Time{}, database.NewDBErrorWithStackTrace(err)
+	}
+
+	return user.ID(row.UserID), row.CreatedAt, nil
+}
+
 func (r userRepository) SaveLoginKeyForUserID(ctx context.Context, conn database.Connection, id user.ID, loginKey domain.LoginKey, now time.Time) error {
 	err := conn.Queries().InsertLoginKeyForUserID(ctx, queries.InsertLoginKeyForUserIDParams{
 		UserID:    int32(id),
@@ -60,17 +152,31 @@ func (r userRepository) SaveLoginKeyForUserID(ctx context.Context, conn database
 	return nil
 }
 
-func (r userRepository) DeleteLoginKeyByUserID(ctx context.Context, conn database.Connection, id user.ID) error {
-	err := conn.Queries().DeleteLoginKey(ctx, int32(id))
+func (r userRepository) DeleteLoginKey(ctx context.Context, conn database.Connection, loginKey domain.LoginKey) error {
+	rows, err := conn.Queries().DeleteLoginKey(ctx, int64(loginKey))
 	if err != nil {
 		return database.NewDBErrorWithStackTrace(err)
+	} else if rows == 0 {
+		return domain.UserNotFoundByLoginKeyError
 	}
 	return nil
 }
 
-func (r userRepository) SaveUserSub(ctx context.Context, conn database.Connection, userID user.ID, sub string, now time.Time) error {
+func (r userRepository) DeleteExpiredLoginKeys(ctx context.Context, conn database.Connection, expiredAt time.Time, limit int32) (int64, error) {
+	rows, err := conn.Queries().DeleteExpiredLoginKeys(ctx, queries.DeleteExpiredLoginKeysParams{
+		CreatedAt: expiredAt,
+		Limit:     limit,
+	})
+	if err != nil {
+		return 0, database.NewDBErrorWithStackTrace(err)
+	}
+	return rows, nil
+}
+
+func (r userRepository) SaveUserSub(ctx context.Context, conn database.Connection, userID user.ID, provider string, sub string, now time.Time) error {
 	row, err := conn.Queries().InsertSubForUserID(ctx, queries.InsertSubForUserIDParams{
 		UserID:    int32(userID),
+		Provider:  provider,
 		Sub:       sub,
 		CreatedAt: now,
 	})
@@ -82,3 +188,52 @@ func (r userRepository) SaveUserSub(ctx context.Context, conn database.Connectio
 
 	return nil
 }
+
+func (r userRepository) GetIdentitiesByUserID(ctx context.Context, conn database.Connection, userID user.ID) ([]domain.Identity, error) {
+	rows, err := conn.Queries().GetUserSubsByUserID(ctx, int32(userID))
+	if err != nil {
+		return nil, database.NewDBErrorWithStackTrace(err)
+	}
+
+	identities := make([]domain.Identity, 0, len(rows))
+	for _, row := range rows {
+		identities = append(identities, domain.Identity{
+			Provider:  row.Provider,
+			Subject:   row.Sub,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return identities, nil
+}
+
+func (r userRepository) GetIdentitiesByUserIDForUpdate(ctx context.Context, conn database.Connection, userID user.ID) ([]domain.Identity, error) {
+	rows, err := conn.Queries().GetUserSubsByUserIDForUpdate(ctx, int32(userID))
+	if err != nil {
+		return nil, database.NewDBErrorWithStackTrace(err)
+	}
+
+	identities := make([]domain.Identity, 0, len(rows))
+	for _, row := range rows {
+		identities = append(identities, domain.Identity{
+			Provider:  row.Provider,
+			Subject:   row.Sub,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return identities, nil
+}
+
+func (r userRepository) DeleteUserSub(ctx context.Context, conn database.Connection, userID user.ID, provider string, sub string) error {
+	rows, err := conn.Queries().DeleteUserSubByUserID(ctx, queries.DeleteUserSubByUserIDParams{
+		UserID:   int32(userID),
+		Provider: provider,
+		Sub:      sub,
+	})
+	if err != nil {
+		return database.NewDBErrorWithStackTrace(err)
+	} else if rows == 0 {
+		return domain.IdentityNotFoundError
+	}
+
+	return nil
+}
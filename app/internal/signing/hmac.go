@@ -0,0 +1,114 @@
+package signing
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/okocraft/authlib/jwtclaims"
+)
+
+// NewHMACSigner creates a jwtclaims.JWTSigner that signs tokens with the current key and accepts tokens signed with the retired keys.
+//
+// The key used for signing is written to the kid header.
+// Tokens without kid, which were issued before key rotation was introduced, are verified by trying every key.
+func NewHMACSigner(method *jwt.SigningMethodHMAC, current []byte, retired ...[]byte) jwtclaims.JWTSigner {
+	keys := make(map[string][]byte, len(retired)+1)
+	ordered := make([][]byte, 0, len(retired)+1)
+	for _, key := range append([][]byte{current}, retired...) {
+		kid := hmacKeyID(key)
+		if _, ok := keys[kid]; ok {
+			continue
+		}
+		keys[kid] = key
+		ordered = append(ordered, key)
+	}
+
+	return &hmacSigner{
+		method:     method,
+		currentKID: hmacKeyID(current),
+		current:    current,
+		keys:       keys,
+		ordered:    ordered,
+	}
+}
+
+// hmacKeyID returns the identifier of the secret that does not reveal the secret itself.
+func hmacKeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return base64.RawURLEncoding.EncodeToString(sum[:8])
+}
+
+type hmacSigner struct {
+	method     *jwt.SigningMethodHMAC
+	currentKID string
+	current    []byte
+	keys       map[string][]byte
+	ordered    [][]byte
+}
+
+func (s hmacSigner) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.method, claims)
+	token.Header["kid"] = s.currentKID
+	return token.SignedString(s.current)
+}
+
+func (s hmacSigner) VerifyAndParse(tokenString string) (jwt.MapClaims, error) {
+	kid, hasKID, err := s.readKeyID(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasKID {
+		key, ok := s.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownKeyID, kid)
+		}
+		return s.verifyAndParse(tokenString, key)
+	}
+
+	var errs []error
+	for _, key := range s.ordered {
+		claims, err := s.verifyAndParse(tokenString, key)
+		if err == nil {
+			return claims, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (s hmacSigner) readKeyID(tokenString string) (string, bool, error) {
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		return "", false, err
+	}
+
+	value, ok := token.Header["kid"]
+	if !ok {
+		return "", false, nil
+	}
+
+	kid, ok := value.(string)
+	if !ok {
+		return "", false, fmt.Errorf("%w: %v", ErrUnknownKeyID, value)
+	}
+	return kid, true, nil
+}
+
+func (s hmacSigner) verifyAndParse(tokenString string, key []byte) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(*jwt.Token) (any, error) {
+		return key, nil
+	}, jwt.WithValidMethods([]string{s.method.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+		return claims, nil
+	}
+
+	return nil, errors.New("invalid claims")
+}
@@ -0,0 +1,81 @@
+package signing_test
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/okocraft/auth-service/internal/signing"
+	"github.com/okocraft/authlib/jwtclaims"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRandomSecret(t *testing.T) []byte {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	require.NoError(t, err)
+	return secret
+}
+
+func TestHMACSigner_VerifyAndParse(t *testing.T) {
+	current := newRandomSecret(t)
+	retired := newRandomSecret(t)
+	signer := signing.NewHMACSigner(jwt.SigningMethodHS512, current, retired)
+
+	claims := func() jwt.MapClaims {
+		return jwt.MapClaims{"sub": "1", "exp": jwt.NewNumericDate(time.Now().Add(time.Minute))}
+	}
+
+	tests := []struct {
+		name    string
+		signer  jwtclaims.JWTSigner
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success: current key",
+			signer:  signer,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success: retired key",
+			signer:  signing.NewHMACSigner(jwt.SigningMethodHS512, retired),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success: token without kid",
+			signer:  jwtclaims.NewJWTSigner(jwt.SigningMethodHS512, retired),
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "error: unknown key",
+			signer: signing.NewHMACSigner(jwt.SigningMethodHS512, newRandomSecret(t)),
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, signing.ErrUnknownKeyID)
+			},
+		},
+		{
+			name:    "error: unknown key without kid",
+			signer:  jwtclaims.NewJWTSigner(jwt.SigningMethodHS512, newRandomSecret(t)),
+			wantErr: assert.Error,
+		},
+		{
+			name:    "error: unexpected signing method",
+			signer:  signing.NewHMACSigner(jwt.SigningMethodHS256, current),
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := tt.signer.Sign(claims())
+			require.NoError(t, err)
+
+			got, err := signer.VerifyAndParse(token)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+			assert.Equal(t, "1", got["sub"])
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+)
+
+// JWK is a public JSON Web Key (RFC 7517) that can be published to verify signed tokens.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+}
+
+// JWKS is a JSON Web Key Set (RFC 7517 Section 5).
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func newEd25519JWK(publicKey ed25519.PublicKey) JWK {
+	x := base64.RawURLEncoding.EncodeToString(publicKey)
+	return JWK{
+		KeyType:   "OKP",
+		Curve:     "Ed25519",
+		X:         x,
+		KeyID:     ed25519Thumbprint(x),
+		Use:       "sig",
+		Algorithm: "EdDSA",
+	}
+}
+
+// ed25519Thumbprint computes the JWK thumbprint (RFC 7638, RFC 8037 Section 2) of the Ed25519 public key.
+func ed25519Thumbprint(x string) string {
+	// the members must be in lexicographic order and without whitespace, which json.Marshal does for a struct declared in that order
+	data, _ := json.Marshal(struct {
+		Crv string `json:"crv"`
+		Kty string `json:"kty"`
+		X   string `json:"x"`
+	}{Crv: "Ed25519", Kty: "OKP", X: x})
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,89 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/okocraft/authlib/jwtclaims"
+)
+
+var ErrUnknownKeyID = errors.New("unknown kid")
+
+// KeySigner signs tokens with an asymmetric key so that other services can verify them with the published JWKS.
+type KeySigner interface {
+	jwtclaims.JWTSigner
+	KeyID() string
+	JWKS() JWKS
+}
+
+// NewEd25519Signer creates a KeySigner that signs tokens using EdDSA with the current key.
+//
+// The retired keys are no longer used for signing, but are still published and accepted until the tokens signed with them expire.
+// The key id is the JWK thumbprint of the public key, so it does not change as long as the same key is used.
+func NewEd25519Signer(current ed25519.PrivateKey, retired ...ed25519.PrivateKey) KeySigner {
+	currentJWK := newEd25519JWK(current.Public().(ed25519.PublicKey))
+
+	publicKeys := make(map[string]ed25519.PublicKey, len(retired)+1)
+	jwks := make([]JWK, 0, len(retired)+1)
+	for _, key := range append([]ed25519.PrivateKey{current}, retired...) {
+		publicKey := key.Public().(ed25519.PublicKey)
+		jwk := newEd25519JWK(publicKey)
+		if _, ok := publicKeys[jwk.KeyID]; ok {
+			continue
+		}
+		publicKeys[jwk.KeyID] = publicKey
+		jwks = append(jwks, jwk)
+	}
+
+	return &ed25519Signer{
+		privateKey: current,
+		currentKID: currentJWK.KeyID,
+		publicKeys: publicKeys,
+		jwks:       jwks,
+	}
+}
+
+type ed25519Signer struct {
+	privateKey ed25519.PrivateKey
+	currentKID string
+	publicKeys map[string]ed25519.PublicKey
+	jwks       []JWK
+}
+
+func (s ed25519Signer) KeyID() string {
+	return s.currentKID
+}
+
+func (s ed25519Signer) JWKS() JWKS {
+	keys := make([]JWK, len(s.jwks))
+	copy(keys, s.jwks)
+	return JWKS{Keys: keys}
+}
+
+func (s ed25519Signer) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = s.currentKID
+	return token.SignedString(s.privateKey)
+}
+
+func (s ed25519Signer) VerifyAndParse(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		publicKey, ok := s.publicKeys[kid]
+		if !ok {
+			return nil, fmt.Errorf("%w: %v", ErrUnknownKeyID, token.Header["kid"])
+		}
+		return publicKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+		return claims, nil
+	}
+
+	return nil, errors.New("invalid claims")
+}
@@ -0,0 +1,136 @@
+package signing_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/okocraft/auth-service/internal/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// https://www.rfc-editor.org/rfc/rfc8037#appendix-A
+func newRFC8037Key(t *testing.T) ed25519.PrivateKey {
+	seed, err := base64.RawURLEncoding.DecodeString("nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A")
+	require.NoError(t, err)
+	return ed25519.NewKeyFromSeed(seed)
+}
+
+func newRandomKey(t *testing.T) ed25519.PrivateKey {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func TestNewEd25519Signer_JWKS(t *testing.T) {
+	signer := signing.NewEd25519Signer(newRFC8037Key(t))
+
+	assert.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", signer.KeyID())
+	assert.Equal(t, signing.JWKS{Keys: []signing.JWK{{
+		KeyType:   "OKP",
+		Curve:     "Ed25519",
+		X:         "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo",
+		KeyID:     "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k",
+		Use:       "sig",
+		Algorithm: "EdDSA",
+	}}}, signer.JWKS())
+}
+
+func TestEd25519Signer_VerifyAndParse(t *testing.T) {
+	key := newRandomKey(t)
+	signer := signing.NewEd25519Signer(key)
+	claims := func() jwt.MapClaims {
+		return jwt.MapClaims{"sub": "1", "exp": jwt.NewNumericDate(time.Now().Add(time.Minute))}
+	}
+
+	tests := []struct {
+		name    string
+		token   func(t *testing.T) string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			token: func(t *testing.T) string {
+				token, err := signer.Sign(claims())
+				require.NoError(t, err)
+				return token
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error: signed by another key",
+			token: func(t *testing.T) string {
+				token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims())
+				token.Header["kid"] = signer.KeyID()
+				signed, err := token.SignedString(newRandomKey(t))
+				require.NoError(t, err)
+				return signed
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error: unknown kid",
+			token: func(t *testing.T) string {
+				token, err := signing.NewEd25519Signer(newRandomKey(t)).Sign(claims())
+				require.NoError(t, err)
+				return token
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, signing.ErrUnknownKeyID)
+			},
+		},
+		{
+			name: "error: signed with HMAC using the public key",
+			token: func(t *testing.T) string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims())
+				token.Header["kid"] = signer.KeyID()
+				signed, err := token.SignedString([]byte(key.Public().(ed25519.PublicKey)))
+				require.NoError(t, err)
+				return signed
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error: expired",
+			token: func(t *testing.T) string {
+				token, err := signer.Sign(jwt.MapClaims{"exp": jwt.NewNumericDate(time.Now().Add(-time.Minute))})
+				require.NoError(t, err)
+				return token
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := signer.VerifyAndParse(tt.token(t))
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+			assert.Equal(t, "1", got["sub"])
+		})
+	}
+}
+
+func TestEd25519Signer_KeyRotation(t *testing.T) {
+	oldKey := newRandomKey(t)
+	newKey := newRandomKey(t)
+
+	oldSigner := signing.NewEd25519Signer(oldKey)
+	token, err := oldSigner.Sign(jwt.MapClaims{"exp": jwt.NewNumericDate(time.Now().Add(time.Minute))})
+	require.NoError(t, err)
+
+	rotated := signing.NewEd25519Signer(newKey, oldKey)
+	assert.NotEqual(t, oldSigner.KeyID(), rotated.KeyID())
+
+	jwks := rotated.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, rotated.KeyID(), jwks.Keys[0].KeyID)
+	assert.Equal(t, oldSigner.KeyID(), jwks.Keys[1].KeyID)
+
+	_, err = rotated.VerifyAndParse(token)
+	assert.NoError(t, err, "token signed with the retired key should be accepted")
+}
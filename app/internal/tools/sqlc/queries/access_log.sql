@@ -1,3 +1,64 @@
 -- name: InsertAccessLog :exec
-INSERT INTO users_access_logs (user_id, action_type, login_id, ip, user_agent, created_at)
-VALUES (?, ?, ?, ?, ?, ?);
+INSERT INTO users_access_logs (user_id, action_type, reason, login_id, ip, user_agent, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetLatestAccessLogsOfLoginsByUserID :many
+SELECT login_id, ip, user_agent, created_at
+FROM users_access_logs
+WHERE id IN (SELECT MAX(id)
+             FROM users_access_logs
+             WHERE users_access_logs.user_id = ?
+               AND users_access_logs.login_id IS NOT NULL
+             GROUP BY login_id);
+
+-- name: GetAccessLogsByUserID :many
+SELECT id, action_type, reason, login_id, ip, user_agent, created_at
+FROM users_access_logs
+WHERE user_id = ?
+  AND id < ?
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: GetAccessLogsByUserIDAndLoginID :many
+SELECT id, action_type, reason, login_id, ip, user_agent, created_at
+FROM users_access_logs
+WHERE user_id = ?
+  AND login_id = ?
+  AND id < ?
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: GetFailedAccessLogs :many
+SELECT users_access_logs.id,
+       users_access_logs.user_id,
+       users.uuid AS user_uuid,
+       users_access_logs.action_type,
+       users_access_logs.reason,
+       users_access_logs.login_id,
+       users_access_logs.ip,
+       users_access_logs.user_agent,
+       users_access_logs.created_at
+FROM users_access_logs
+         LEFT JOIN users ON users.id = users_access_logs.user_id
+WHERE users_access_logs.reason <> 0
+  AND users_access_logs.id < ?
+ORDER BY users_access_logs.id DESC
+LIMIT ?;
+
+-- name: GetFailedAccessLogsByIP :many
+SELECT users_access_logs.id,
+       users_access_logs.user_id,
+       users.uuid AS user_uuid,
+       users_access_logs.action_type,
+       users_access_logs.reason,
+       users_access_logs.login_id,
+       users_access_logs.ip,
+       users_access_logs.user_agent,
+       users_access_logs.created_at
+FROM users_access_logs
+         LEFT JOIN users ON users.id = users_access_logs.user_id
+WHERE users_access_logs.ip = ?
+  AND users_access_logs.reason <> 0
+  AND users_access_logs.id < ?
+ORDER BY users_access_logs.id DESC
+LIMIT ?;
@@ -11,6 +11,12 @@ SELECT id, user_id
 FROM users_refresh_tokens
 WHERE jti = ?;
 
+-- name: ConsumeRefreshToken :execrows
+UPDATE users_refresh_tokens
+SET consumed_at = ?
+WHERE id = ?
+  AND consumed_at IS NULL;
+
 -- name: DeleteRefreshTokensByLoginID :exec
 DELETE
 FROM users_refresh_tokens
@@ -23,19 +29,60 @@ WHERE users_access_tokens.refresh_token_id IN (SELECT users_refresh_tokens.id
                                                FROM users_refresh_tokens
                                                WHERE users_refresh_tokens.login_id = ?);
 
--- name: GetUserIDByAccessTokenJTI :one
-SELECT user_id
+-- name: DeleteRefreshTokensByUserID :exec
+DELETE
 FROM users_refresh_tokens
-WHERE id = (SELECT refresh_token_id
-            FROM users_access_tokens
-            WHERE users_access_tokens.jti = ?);
+WHERE user_id = ?;
+
+-- name: DeleteAccessTokensByUserID :exec
+DELETE
+FROM users_access_tokens
+WHERE users_access_tokens.refresh_token_id IN (SELECT users_refresh_tokens.id
+                                               FROM users_refresh_tokens
+                                               WHERE users_refresh_tokens.user_id = ?);
+
+-- name: GetUserByAccessTokenJTI :one
+SELECT users.id AS user_id, users.uuid AS user_uuid, users_refresh_tokens.login_id
+FROM users_access_tokens
+         JOIN users_refresh_tokens ON users_refresh_tokens.id = users_access_tokens.refresh_token_id
+         JOIN users ON users.id = users_refresh_tokens.user_id
+WHERE users_access_tokens.jti = ?;
 
 -- name: DeleteExpiredAccessTokens :execrows
 DELETE
 FROM users_access_tokens
-WHERE created_at < ?;
+WHERE created_at < ?
+LIMIT ?;
 
 -- name: DeleteExpiredRefreshTokens :execrows
 DELETE
 FROM users_refresh_tokens
-WHERE created_at < ?;
+WHERE created_at < ?
+LIMIT ?;
+
+-- name: InsertUsedLoginState :execrows
+INSERT IGNORE INTO used_login_states (jti, expires_at)
+VALUES (?, ?);
+
+-- name: DeleteExpiredUsedLoginStates :execrows
+DELETE
+FROM used_login_states
+WHERE expires_at < ?
+LIMIT ?;
+
+-- name: GetSessionsByUserID :many
+SELECT login_id,
+       CAST(MIN(created_at) AS DATETIME) AS logged_in_at,
+       CAST(MAX(created_at) AS DATETIME) AS last_refreshed_at
+FROM users_refresh_tokens
+WHERE user_id = ?
+  AND created_at >= ?
+GROUP BY login_id
+HAVING SUM(consumed_at IS NULL) > 0
+ORDER BY last_refreshed_at DESC;
+
+-- name: ExistsRefreshTokenByUserIDAndLoginID :one
+SELECT EXISTS(SELECT 1
+              FROM users_refresh_tokens
+              WHERE user_id = ?
+                AND login_id = ?) AS found;
@@ -0,0 +1,24 @@
+-- name: InsertRateLimitBucketIfNotExists :exec
+INSERT INTO rate_limit_buckets (bucket_key, tokens, updated_at, locked_until, expires_at)
+VALUES (?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE bucket_key = bucket_key;
+
+-- name: GetRateLimitBucketForUpdate :one
+SELECT tokens, updated_at, locked_until
+FROM rate_limit_buckets
+WHERE bucket_key = ?
+FOR UPDATE;
+
+-- name: UpdateRateLimitBucket :exec
+UPDATE rate_limit_buckets
+SET tokens       = ?,
+    updated_at   = ?,
+    locked_until = ?,
+    expires_at   = ?
+WHERE bucket_key = ?;
+
+-- name: DeleteExpiredRateLimitBuckets :execrows
+DELETE
+FROM rate_limit_buckets
+WHERE expires_at < ?
+LIMIT ?;
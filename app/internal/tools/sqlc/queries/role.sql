@@ -0,0 +1,54 @@
+-- name: UpsertRoleByName :execlastid
+INSERT INTO roles (name, created_at)
+VALUES (?, ?)
+ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id);
+
+-- name: GetRoleIDByName :one
+SELECT id
+FROM roles
+WHERE name = ?;
+
+-- name: GetRolesWithPermissions :many
+SELECT roles.id, roles.name, roles.created_at, roles_permissions.permission
+FROM roles
+         LEFT JOIN roles_permissions ON roles.id = roles_permissions.role_id
+ORDER BY roles.name, roles_permissions.permission;
+
+-- name: DeleteRole :execrows
+DELETE
+FROM roles
+WHERE id = ?;
+
+-- name: InsertPermissionForRole :exec
+INSERT IGNORE INTO roles_permissions (role_id, permission, created_at)
+VALUES (?, ?, ?);
+
+-- name: DeletePermissionFromRole :execrows
+DELETE
+FROM roles_permissions
+WHERE role_id = ?
+  AND permission = ?;
+
+-- name: InsertRoleForUserID :execrows
+INSERT IGNORE INTO users_roles (user_id, role_id, created_at)
+VALUES (?, ?, ?);
+
+-- name: DeleteRoleFromUserID :execrows
+DELETE
+FROM users_roles
+WHERE user_id = ?
+  AND role_id = ?;
+
+-- name: GetRoleNamesByUserID :many
+SELECT roles.name
+FROM users_roles
+         INNER JOIN roles ON users_roles.role_id = roles.id
+WHERE users_roles.user_id = ?
+ORDER BY roles.name;
+
+-- name: GetPermissionsByUserID :many
+SELECT DISTINCT roles_permissions.permission
+FROM users_roles
+         INNER JOIN roles_permissions ON users_roles.role_id = roles_permissions.role_id
+WHERE users_roles.user_id = ?
+ORDER BY roles_permissions.permission;
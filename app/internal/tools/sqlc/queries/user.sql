@@ -1,27 +1,86 @@
+-- name: UpsertUserByUUID :execlastid
+INSERT INTO users (uuid, created_at)
+VALUES (?, ?)
+ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id);
+
+-- name: GetUserIDByUUID :one
+SELECT id
+FROM users
+WHERE uuid = ?;
+
+-- name: GetUserByID :one
+SELECT uuid, created_at, status, suspended_until, status_reason
+FROM users
+WHERE id = ?;
+
+-- name: GetUserByIDForUpdate :one
+SELECT uuid, created_at, status, suspended_until, status_reason
+FROM users
+WHERE id = ?
+FOR UPDATE;
+
+-- name: UpdateUserStatus :execrows
+UPDATE users
+SET status            = ?,
+    suspended_until   = ?,
+    status_reason     = ?,
+    status_updated_at = ?
+WHERE id = ?;
+
 -- name: GetUserIDBySub :one
 SELECT user_id
 FROM users_sub
-WHERE sub = ?;
+WHERE provider = ?
+  AND sub = ?;
 
 -- name: InsertSubForUserID :execrows
-INSERT IGNORE INTO users_sub (user_id, sub, created_at)
-VALUES (?, ?, ?);
+INSERT IGNORE INTO users_sub (user_id, provider, sub, created_at)
+VALUES (?, ?, ?, ?);
+
+-- name: GetUserSubsByUserID :many
+SELECT provider, sub, created_at
+FROM users_sub
+WHERE user_id = ?
+ORDER BY id;
+
+-- name: GetUserSubsByUserIDForUpdate :many
+SELECT provider, sub, created_at
+FROM users_sub
+WHERE user_id = ?
+ORDER BY id
+FOR UPDATE;
+
+-- name: DeleteUserSubByUserID :execrows
+DELETE
+FROM users_sub
+WHERE user_id = ?
+  AND provider = ?
+  AND sub = ?;
 
 -- name: DeleteUserSubBySub :exec
 DELETE
 FROM users_sub
-WHERE sub = ?;
+WHERE provider = ?
+  AND sub = ?;
 
 -- name: GetUserIDByLoginKey :one
-SELECT user_id
+SELECT user_id, created_at
 FROM users_login_key
 WHERE login_key = ?;
 
 -- name: InsertLoginKeyForUserID :exec
 INSERT INTO users_login_key (user_id, login_key, created_at)
-VALUES (?, ?, ?);
+VALUES (?, ?, ?)
+ON DUPLICATE KEY UPDATE login_key  = VALUES(login_key),
+                        created_at = VALUES(created_at);
+
+-- name: DeleteLoginKey :execrows
+DELETE
+FROM users_login_key
+WHERE login_key = ?;
 
--- name: DeleteLoginKey :exec
+-- name: DeleteExpiredLoginKeys :execrows
 DELETE
 FROM users_login_key
-WHERE user_id = ?;
+WHERE created_at < ?
+LIMIT ?;
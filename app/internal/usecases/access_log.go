@@ -2,8 +2,10 @@ package usecases
 
 import (
 	"context"
+	"net"
 
 	"github.com/Siroshun09/serrors"
+	"github.com/gofrs/uuid/v5"
 	"github.com/okocraft/auth-service/internal/domain"
 	"github.com/okocraft/auth-service/internal/repositories"
 	"github.com/okocraft/auth-service/internal/repositories/database"
@@ -12,6 +14,10 @@ import (
 
 type AccessLogUsecase interface {
 	SaveAccessLogByUserID(ctx context.Context, userID user.ID, accessLog domain.AccessLogParams) error
+	SaveUnattributedAccessLog(ctx context.Context, accessLog domain.AccessLogParams) error
+	GetAccessLogs(ctx context.Context, userID user.ID, page domain.AccessLogPageParams) (domain.AccessLogPage, error)
+	GetAccessLogsOfLogin(ctx context.Context, userID user.ID, loginID uuid.UUID, page domain.AccessLogPageParams) (domain.AccessLogPage, error)
+	GetFailedAccessLogs(ctx context.Context, ip net.IP, page domain.AccessLogPageParams) (domain.AccessLogPage, error)
 }
 
 func NewAccessLogUsecase(db database.DB, repo repositories.AccessLogRepository, userRepo repositories.UserRepository) AccessLogUsecase {
@@ -35,3 +41,59 @@ func (u accessLogUsecase) SaveAccessLogByUserID(ctx context.Context, userID user
 	}
 	return nil
 }
+
+// SaveUnattributedAccessLog saves the failed attempt that cannot be attributed to any user.
+func (u accessLogUsecase) SaveUnattributedAccessLog(ctx context.Context, accessLog domain.AccessLogParams) error {
+	err := u.repo.SaveAccessLog(ctx, u.db.Conn(), 0, accessLog)
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+	return nil
+}
+
+// GetAccessLogs returns a page of the access logs of the user, newest first.
+func (u accessLogUsecase) GetAccessLogs(ctx context.Context, userID user.ID, page domain.AccessLogPageParams) (domain.AccessLogPage, error) {
+	// fetches one more row to know whether the next page exists
+	accessLogs, err := u.repo.GetAccessLogsByUserID(ctx, u.db.Conn(), userID, page.Before, page.Limit+1)
+	if err != nil {
+		return domain.AccessLogPage{}, serrors.WithStackTrace(err)
+	}
+	return newAccessLogPage(accessLogs, page.Limit), nil
+}
+
+// GetAccessLogsOfLogin returns a page of the access logs of the login, newest first.
+func (u accessLogUsecase) GetAccessLogsOfLogin(ctx context.Context, userID user.ID, loginID uuid.UUID, page domain.AccessLogPageParams) (domain.AccessLogPage, error) {
+	accessLogs, err := u.repo.GetAccessLogsByUserIDAndLoginID(ctx, u.db.Conn(), userID, loginID, page.Before, page.Limit+1)
+	if err != nil {
+		return domain.AccessLogPage{}, serrors.WithStackTrace(err)
+	}
+	return newAccessLogPage(accessLogs, page.Limit), nil
+}
+
+// GetFailedAccessLogs returns a page of the failed attempts of all users, newest first.
+// If ip is not nil, only the attempts from the IP address are returned.
+func (u accessLogUsecase) GetFailedAccessLogs(ctx context.Context, ip net.IP, page domain.AccessLogPageParams) (domain.AccessLogPage, error) {
+	var accessLogs []domain.AccessLog
+	var err error
+	if ip != nil {
+		accessLogs, err = u.repo.GetFailedAccessLogsByIP(ctx, u.db.Conn(), ip, page.Before, page.Limit+1)
+	} else {
+		accessLogs, err = u.repo.GetFailedAccessLogs(ctx, u.db.Conn(), page.Before, page.Limit+1)
+	}
+	if err != nil {
+		return domain.AccessLogPage{}, serrors.WithStackTrace(err)
+	}
+	return newAccessLogPage(accessLogs, page.Limit), nil
+}
+
+func newAccessLogPage(accessLogs []domain.AccessLog, limit int32) domain.AccessLogPage {
+	if len(accessLogs) <= int(limit) {
+		return domain.AccessLogPage{AccessLogs: accessLogs}
+	}
+
+	accessLogs = accessLogs[:limit]
+	return domain.AccessLogPage{
+		AccessLogs: accessLogs,
+		NextBefore: accessLogs[len(accessLogs)-1].ID,
+	}
+}
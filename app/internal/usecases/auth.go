@@ -7,6 +7,8 @@ import (
 	"errors"
 	"math"
 	"math/big"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
@@ -31,15 +33,20 @@ type AuthUsecase interface {
 	VerifyRefreshToken(ctx context.Context, tokenString string) (jwtclaims.RefreshTokenClaims, error)
 	RefreshToken(ctx context.Context, params domain.RefreshTokenParams) (domain.RefreshedToken, error)
 	InvalidateTokens(ctx context.Context, refreshTokenClaims jwtclaims.RefreshTokenClaims) error
-	CreateLoginKey(ctx context.Context, userID user.ID) (domain.LoginKey, error)
+	InvalidateTokensByLoginID(ctx context.Context, loginID uuid.UUID) error
+	FindLoginByToken(ctx context.Context, tokenString string, preferRefreshToken bool) (user.ID, uuid.UUID, error)
+	IntrospectAccessToken(ctx context.Context, tokenString string) (domain.AccessTokenIntrospection, error)
+	AuthenticateAccessToken(ctx context.Context, tokenString string) (domain.AccessTokenOwner, error)
+	CreateLoginKey(ctx context.Context, userID user.ID) (domain.LoginKey, time.Time, error)
 }
 
-func NewAuthUsecase(conf config.AuthConfig, db database.DB, repo repositories.AuthRepository, userRepo repositories.UserRepository) AuthUsecase {
+func NewAuthUsecase(conf config.AuthConfig, db database.DB, repo repositories.AuthRepository, userRepo repositories.UserRepository, roleRepo repositories.RoleRepository) AuthUsecase {
 	return authUsecase{
 		conf:     conf,
 		db:       db,
 		repo:     repo,
 		userRepo: userRepo,
+		roleRepo: roleRepo,
 	}
 }
 
@@ -48,6 +55,7 @@ type authUsecase struct {
 	db       database.DB
 	repo     repositories.AuthRepository
 	userRepo repositories.UserRepository
+	roleRepo repositories.RoleRepository
 }
 
 func (u authUsecase) CreateStateJWT(_ context.Context, currentPageURL string, codeVerifier string) (string, error) {
@@ -114,12 +122,25 @@ func (u authUsecase) CreateStateJWTWithLoginKey(_ context.Context, loginKey doma
 	return tokenString, nil
 }
 
-func (u authUsecase) VerifyStateJWT(_ context.Context, tokenString string) (jwtclaims.LoginStateClaimType, jwt.MapClaims, error) {
+func (u authUsecase) VerifyStateJWT(ctx context.Context, tokenString string) (jwtclaims.LoginStateClaimType, jwt.MapClaims, error) {
 	claims, err := u.conf.JWTSigner.VerifyAndParse(tokenString)
 	if err != nil {
 		return jwtclaims.LoginStateClaimTypeUnknown, nil, serrors.WithStackTrace(err)
 	}
 
+	baseClaims, err := jwtclaims.ReadBaseClaimsFrom(claims)
+	if err != nil {
+		return jwtclaims.LoginStateClaimTypeUnknown, nil, serrors.WithStackTrace(err)
+	}
+
+	// the state is consumed here so that a captured callback url cannot be replayed until the state expires
+	err = u.repo.ConsumeLoginState(ctx, u.db.Conn(), baseClaims.JTI, baseClaims.ExpiresAt)
+	if errors.Is(err, domain.LoginStateAlreadyUsedError) {
+		return jwtclaims.LoginStateClaimTypeUnknown, nil, serrors.WithStackTrace(domain.NewUnauthorizedError(err))
+	} else if err != nil {
+		return jwtclaims.LoginStateClaimTypeUnknown, nil, serrors.WithStackTrace(err)
+	}
+
 	claimType := jwtclaims.GetLoginStateClaimType(claims)
 	return claimType, claims, nil
 }
@@ -162,7 +183,19 @@ func (u authUsecase) CreateRefreshToken(ctx context.Context, userID user.ID) (uu
 	createdAt := time.Now()
 	expiresAt := createdAt.Add(u.conf.RefreshTokenExpireDuration)
 
-	err = u.repo.SaveRefreshToken(ctx, u.db.Conn(), userID, refreshTokenJTI, loginID, expiresAt)
+	err = u.db.WithTx(ctx, func(ctx context.Context, tx database.Connection) error {
+		// lock the user so that a concurrent suspension cannot miss the refresh token created here
+		usr, err := u.userRepo.GetUserByIDForUpdate(ctx, tx, userID)
+		if err != nil {
+			return serrors.WithStackTrace(err)
+		}
+
+		if err := usr.Status.Check(createdAt); err != nil {
+			return serrors.WithStackTrace(err)
+		}
+
+		return u.repo.SaveRefreshToken(ctx, tx, userID, refreshTokenJTI, loginID, createdAt)
+	})
 	if err != nil {
 		return uuid.Nil, "", time.Time{}, serrors.WithStackTrace(err)
 	}
@@ -220,7 +253,24 @@ func (u authUsecase) RefreshToken(ctx context.Context, params domain.RefreshToke
 		expiresAt = params.MaxExpiresAt
 	}
 
+	var usr domain.User
+	var authz domain.Authorization
 	err = u.db.WithTx(ctx, func(ctx context.Context, tx database.Connection) error {
+		// the user is locked first, in the same order as the suspension, to avoid deadlocks
+		usr, err = u.userRepo.GetUserByIDForUpdate(ctx, tx, params.UserID)
+		if err != nil {
+			return serrors.WithStackTrace(err)
+		}
+
+		if err := usr.Status.Check(createdAt); err != nil {
+			return serrors.WithStackTrace(err)
+		}
+
+		err = u.repo.ConsumeRefreshToken(ctx, tx, params.RefreshTokenID, createdAt)
+		if err != nil {
+			return serrors.WithStackTrace(err)
+		}
+
 		err = u.repo.SaveAccessToken(ctx, tx, params.RefreshTokenID, accessTokenJTI, createdAt)
 		if err != nil {
 			return serrors.WithStackTrace(err)
@@ -231,9 +281,22 @@ func (u authUsecase) RefreshToken(ctx context.Context, params domain.RefreshToke
 			return serrors.WithStackTrace(err)
 		}
 
+		authz, err = u.roleRepo.GetAuthorizationByUserID(ctx, tx, params.UserID)
+		if err != nil {
+			return serrors.WithStackTrace(err)
+		}
+
 		return nil
 	})
-	if err != nil {
+	if errors.Is(err, domain.RefreshTokenAlreadyConsumedError) {
+		// the refresh token has been replayed, so the login may have been stolen
+		if revokeErr := u.InvalidateTokensByLoginID(ctx, params.LoginID); revokeErr != nil {
+			return domain.RefreshedToken{}, serrors.WithStackTrace(errors.Join(err, revokeErr))
+		}
+		return domain.RefreshedToken{}, serrors.WithStackTrace(domain.NewUnauthorizedError(err))
+	} else if errors.Is(err, domain.AccountSuspendedError) {
+		return domain.RefreshedToken{}, serrors.WithStackTrace(domain.NewUnauthorizedError(err))
+	} else if err != nil {
 		return domain.RefreshedToken{}, serrors.WithStackTrace(err)
 	}
 
@@ -259,7 +322,7 @@ func (u authUsecase) RefreshToken(ctx context.Context, params domain.RefreshToke
 		return domain.RefreshedToken{}, serrors.WithStackTrace(err)
 	}
 
-	accessTokenString, err := u.conf.JWTSigner.Sign(accessToken.CreateJWTClaims())
+	accessTokenString, err := u.conf.AccessTokenSigner.Sign(createAccessTokenJWTClaims(accessToken, usr, authz))
 	if err != nil {
 		return domain.RefreshedToken{}, serrors.WithStackTrace(err)
 	}
@@ -271,14 +334,53 @@ func (u authUsecase) RefreshToken(ctx context.Context, params domain.RefreshToke
 	}, nil
 }
 
+// createAccessTokenJWTClaims adds the public user UUID as the sub claim and the roles and the permissions of the user,
+// so that the services verifying the token with the JWKS can identify and authorize the user without asking this service.
+//
+// The permissions are put in the space-delimited scope claim as described in RFC 9068.
+func createAccessTokenJWTClaims(accessToken jwtclaims.AccessTokenClaims, usr domain.User, authz domain.Authorization) jwt.Claims {
+	claims := accessToken.CreateJWTClaims().(jwt.MapClaims)
+	claims["sub"] = usr.UUID.String()
+	claims["roles"] = authz.Roles
+	claims["scope"] = strings.Join(authz.Permissions, " ")
+	return claims
+}
+
+// readAuthorizationFrom reads the claims added by createAccessTokenJWTClaims.
+// The access tokens issued before they were added are treated as having no roles.
+func readAuthorizationFrom(claims jwt.MapClaims) domain.Authorization {
+	authz := domain.Authorization{
+		Roles:       []string{},
+		Permissions: []string{},
+	}
+
+	if roles, ok := claims["roles"].([]any); ok {
+		for _, role := range roles {
+			if name, ok := role.(string); ok {
+				authz.Roles = append(authz.Roles, name)
+			}
+		}
+	}
+
+	if scope, ok := claims["scope"].(string); ok {
+		authz.Permissions = strings.Fields(scope)
+	}
+
+	return authz
+}
+
 func (u authUsecase) InvalidateTokens(ctx context.Context, refreshTokenClaims jwtclaims.RefreshTokenClaims) error {
+	return u.InvalidateTokensByLoginID(ctx, refreshTokenClaims.LoginID)
+}
+
+func (u authUsecase) InvalidateTokensByLoginID(ctx context.Context, loginID uuid.UUID) error {
 	err := u.db.WithTx(ctx, func(ctx context.Context, tx database.Connection) error {
-		err := u.repo.DeleteAccessTokensByLoginID(ctx, tx, refreshTokenClaims.LoginID)
+		err := u.repo.DeleteAccessTokensByLoginID(ctx, tx, loginID)
 		if err != nil {
 			return serrors.WithStackTrace(err)
 		}
 
-		err = u.repo.DeleteRefreshTokensByLoginID(ctx, tx, refreshTokenClaims.LoginID)
+		err = u.repo.DeleteRefreshTokensByLoginID(ctx, tx, loginID)
 		if err != nil {
 			return serrors.WithStackTrace(err)
 		}
@@ -292,18 +394,134 @@ func (u authUsecase) InvalidateTokens(ctx context.Context, refreshTokenClaims jw
 	return nil
 }
 
-func (u authUsecase) CreateLoginKey(ctx context.Context, userID user.ID) (domain.LoginKey, error) {
+// IntrospectAccessToken returns whether the access token is valid and has not been revoked.
+//
+// An invalid token is reported as inactive instead of an error, as required by RFC 7662.
+func (u authUsecase) IntrospectAccessToken(ctx context.Context, tokenString string) (domain.AccessTokenIntrospection, error) {
+	accessTokenClaims, owner, err := u.verifyAccessToken(ctx, tokenString)
+	if domain.IsUnauthorizedError(err) {
+		return domain.AccessTokenIntrospection{Active: false}, nil
+	} else if err != nil {
+		return domain.AccessTokenIntrospection{}, serrors.WithStackTrace(err)
+	}
+
+	return domain.AccessTokenIntrospection{
+		Active:        true,
+		UserUUID:      owner.UserUUID,
+		JTI:           accessTokenClaims.JTI,
+		IssuedAt:      accessTokenClaims.NotBefore,
+		ExpiresAt:     accessTokenClaims.ExpiresAt,
+		Authorization: owner.Authorization,
+	}, nil
+}
+
+// AuthenticateAccessToken verifies the access token and returns the user and the login it belongs to.
+//
+// The JTI is always looked up in users_access_tokens, so the access tokens revoked by InvalidateTokens are rejected immediately.
+func (u authUsecase) AuthenticateAccessToken(ctx context.Context, tokenString string) (domain.AccessTokenOwner, error) {
+	_, owner, err := u.verifyAccessToken(ctx, tokenString)
+	if err != nil {
+		return domain.AccessTokenOwner{}, serrors.WithStackTrace(err)
+	}
+	return owner, nil
+}
+
+// verifyAccessToken returns an unauthorized error if the access token is invalid, expired or revoked.
+func (u authUsecase) verifyAccessToken(ctx context.Context, tokenString string) (jwtclaims.AccessTokenClaims, domain.AccessTokenOwner, error) {
+	claims, err := u.conf.AccessTokenSigner.VerifyAndParse(tokenString)
+	if err != nil {
+		return jwtclaims.AccessTokenClaims{}, domain.AccessTokenOwner{}, domain.NewUnauthorizedError(err)
+	}
+
+	accessTokenClaims, err := jwtclaims.ReadAccessTokenClaimsFrom(claims)
+	if err != nil {
+		return jwtclaims.AccessTokenClaims{}, domain.AccessTokenOwner{}, domain.NewUnauthorizedError(err)
+	}
+
+	owner, err := u.repo.GetAccessTokenOwnerByJTI(ctx, u.db.Conn(), accessTokenClaims.JTI)
+	if errors.Is(err, domain.AccessTokenNotFoundError) {
+		return jwtclaims.AccessTokenClaims{}, domain.AccessTokenOwner{}, domain.NewUnauthorizedError(err) // revoked or expired
+	} else if err != nil {
+		return jwtclaims.AccessTokenClaims{}, domain.AccessTokenOwner{}, err
+	}
+
+	owner.Authorization = readAuthorizationFrom(claims)
+
+	return accessTokenClaims, owner, nil
+}
+
+// FindLoginByToken resolves either an access token or a refresh token to the user and the login it belongs to.
+//
+// The token is tried as a refresh token first if preferRefreshToken is true, otherwise as an access token first.
+// Returns domain.LoginNotFoundByTokenError if the token is invalid, expired or already revoked.
+func (u authUsecase) FindLoginByToken(ctx context.Context, tokenString string, preferRefreshToken bool) (user.ID, uuid.UUID, error) {
+	finders := []func(ctx context.Context, tokenString string) (user.ID, uuid.UUID, error){
+		u.findLoginByAccessToken,
+		u.findLoginByRefreshToken,
+	}
+	if preferRefreshToken {
+		slices.Reverse(finders)
+	}
+
+	for _, find := range finders {
+		userID, loginID, err := find(ctx, tokenString)
+		if errors.Is(err, domain.LoginNotFoundByTokenError) {
+			continue
+		} else if err != nil {
+			return 0, uuid.Nil, serrors.WithStackTrace(err)
+		}
+		return userID, loginID, nil
+	}
+
+	return 0, uuid.Nil, domain.LoginNotFoundByTokenError
+}
+
+func (u authUsecase) findLoginByAccessToken(ctx context.Context, tokenString string) (user.ID, uuid.UUID, error) {
+	_, owner, err := u.verifyAccessToken(ctx, tokenString)
+	if domain.IsUnauthorizedError(err) {
+		return 0, uuid.Nil, domain.LoginNotFoundByTokenError
+	} else if err != nil {
+		return 0, uuid.Nil, err
+	}
+
+	return owner.UserID, owner.LoginID, nil
+}
+
+func (u authUsecase) findLoginByRefreshToken(ctx context.Context, tokenString string) (user.ID, uuid.UUID, error) {
+	claims, err := u.conf.JWTSigner.VerifyAndParse(tokenString)
+	if err != nil {
+		return 0, uuid.Nil, domain.LoginNotFoundByTokenError
+	}
+
+	refreshTokenClaims, err := jwtclaims.ReadRefreshTokenClaimsFrom(claims)
+	if err != nil {
+		return 0, uuid.Nil, domain.LoginNotFoundByTokenError
+	}
+
+	userID, _, err := u.repo.GetUserIDAndRefreshTokenIDFromJTI(ctx, u.db.Conn(), refreshTokenClaims.JTI)
+	if errors.Is(err, domain.RefreshTokenIDByJTINotFoundError) {
+		return 0, uuid.Nil, domain.LoginNotFoundByTokenError
+	} else if err != nil {
+		return 0, uuid.Nil, err
+	}
+
+	return userID, refreshTokenClaims.LoginID, nil
+}
+
+func (u authUsecase) CreateLoginKey(ctx context.Context, userID user.ID) (domain.LoginKey, time.Time, error) {
 	key, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
 	if err != nil {
-		return 0, serrors.WithStackTrace(err)
+		return 0, time.Time{}, serrors.WithStackTrace(err)
 	}
 
 	loginKey := domain.LoginKey(key.Int64())
+	createdAt := time.Now()
 
-	err = u.userRepo.SaveLoginKeyForUserID(ctx, u.db.Conn(), userID, loginKey, time.Now())
+	// replaces the login key previously issued for the user
+	err = u.userRepo.SaveLoginKeyForUserID(ctx, u.db.Conn(), userID, loginKey, createdAt)
 	if err != nil {
-		return 0, serrors.WithStackTrace(err)
+		return 0, time.Time{}, serrors.WithStackTrace(err)
 	}
 
-	return loginKey, nil
+	return loginKey, createdAt.Add(u.conf.LoginKeyExpireDuration), nil
 }
@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/okocraft/auth-service/internal/config"
+	"github.com/okocraft/auth-service/internal/domain"
+	"github.com/okocraft/auth-service/internal/repositories"
+	"github.com/okocraft/auth-service/internal/repositories/database"
+)
+
+const cleanupLockName = "auth-service.cleanup"
+
+type CleanupUsecase interface {
+	// DeleteExpiredRecords deletes expired access tokens, refresh tokens, login keys, used login states and rate limit buckets in batches.
+	//
+	// Returns false if another instance is running the cleanup.
+	DeleteExpiredRecords(ctx context.Context) (domain.CleanupResult, bool, error)
+}
+
+func NewCleanupUsecase(authConf config.AuthConfig, cleanupConf config.CleanupConfig, db database.DB, authRepo repositories.AuthRepository, userRepo repositories.UserRepository, rateLimitRepo repositories.RateLimitRepository) CleanupUsecase {
+	return &cleanupUsecase{
+		authConf:      authConf,
+		cleanupConf:   cleanupConf,
+		db:            db,
+		authRepo:      authRepo,
+		userRepo:      userRepo,
+		rateLimitRepo: rateLimitRepo,
+	}
+}
+
+type cleanupUsecase struct {
+	authConf      config.AuthConfig
+	cleanupConf   config.CleanupConfig
+	db            database.DB
+	authRepo      repositories.AuthRepository
+	userRepo      repositories.UserRepository
+	rateLimitRepo repositories.RateLimitRepository
+}
+
+func (u cleanupUsecase) DeleteExpiredRecords(ctx context.Context) (domain.CleanupResult, bool, error) {
+	var result domain.CleanupResult
+	acquired, err := u.db.WithLock(ctx, cleanupLockName, func(ctx context.Context, conn database.Connection) error {
+		now := time.Now()
+
+		deleted, err := u.deleteInBatches(ctx, func(ctx context.Context) (int64, error) {
+			return u.authRepo.DeleteExpiredAccessTokens(ctx, conn, now.Add(-u.authConf.AccessTokenExpireDuration), u.cleanupConf.BatchSize)
+		})
+		result.DeletedAccessTokens = deleted
+		if err != nil {
+			return err
+		}
+
+		deleted, err = u.deleteInBatches(ctx, func(ctx context.Context) (int64, error) {
+			return u.authRepo.DeleteExpiredRefreshTokens(ctx, conn, now.Add(-u.authConf.RefreshTokenExpireDuration), u.cleanupConf.BatchSize)
+		})
+		result.DeletedRefreshTokens = deleted
+		if err != nil {
+			return err
+		}
+
+		deleted, err = u.deleteInBatches(ctx, func(ctx context.Context) (int64, error) {
+			return u.userRepo.DeleteExpiredLoginKeys(ctx, conn, now.Add(-u.authConf.LoginKeyExpireDuration), u.cleanupConf.BatchSize)
+		})
+		result.DeletedLoginKeys = deleted
+		if err != nil {
+			return err
+		}
+
+		deleted, err = u.deleteInBatches(ctx, func(ctx context.Context) (int64, error) {
+			return u.authRepo.DeleteExpiredUsedLoginStates(ctx, conn, now, u.cleanupConf.BatchSize)
+		})
+		result.DeletedUsedLoginStates = deleted
+		if err != nil {
+			return err
+		}
+
+		deleted, err = u.deleteInBatches(ctx, func(ctx context.Context) (int64, error) {
+			return u.rateLimitRepo.DeleteExpiredRateLimitBuckets(ctx, conn, now, u.cleanupConf.BatchSize)
+		})
+		result.DeletedRateLimitBuckets = deleted
+		return err
+	})
+	if err != nil {
+		return result, acquired, err
+	}
+
+	return result, acquired, nil
+}
+
+// deleteInBatches calls deleteFn until it deletes fewer rows than the batch size, so that a single DELETE does not hold locks on a large number of rows.
+func (u cleanupUsecase) deleteInBatches(ctx context.Context, deleteFn func(ctx context.Context) (int64, error)) (int64, error) {
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		rows, err := deleteFn(ctx)
+		if err != nil {
+			return total, err
+		}
+
+		total += rows
+		if rows < int64(u.cleanupConf.BatchSize) {
+			return total, nil
+		}
+	}
+}
@@ -11,6 +11,8 @@ type UsecaseFactory struct {
 	DB            database.DB
 	AccessLogRepo repositories.AccessLogRepository
 	AuthRepo      repositories.AuthRepository
+	RateLimitRepo repositories.RateLimitRepository
+	RoleRepo      repositories.RoleRepository
 	UserRepo      repositories.UserRepository
 }
 
@@ -20,6 +22,8 @@ func NewUsecaseFactory(conf config.AuthConfig, db database.DB) UsecaseFactory {
 		DB:            db,
 		AccessLogRepo: repositories.NewAccessLogRepository(),
 		AuthRepo:      repositories.NewAuthRepository(),
+		RateLimitRepo: repositories.NewRateLimitRepository(),
+		RoleRepo:      repositories.NewRoleRepository(),
 		UserRepo:      repositories.NewUserRepository(),
 	}
 }
@@ -29,9 +33,28 @@ func (f UsecaseFactory) NewAccessLogUsecase() AccessLogUsecase {
 }
 
 func (f UsecaseFactory) NewAuthUsecase() AuthUsecase {
-	return NewAuthUsecase(f.AuthConfig, f.DB, f.AuthRepo, f.UserRepo)
+	return NewAuthUsecase(f.AuthConfig, f.DB, f.AuthRepo, f.UserRepo, f.RoleRepo)
 }
 
 func (f UsecaseFactory) NewUserUsecase() UserUsecase {
-	return NewUserUsecase(f.DB, f.UserRepo)
+	return NewUserUsecase(f.AuthConfig, f.DB, f.UserRepo, f.AuthRepo)
+}
+
+func (f UsecaseFactory) NewCleanupUsecase(conf config.CleanupConfig) CleanupUsecase {
+	return NewCleanupUsecase(f.AuthConfig, conf, f.DB, f.AuthRepo, f.UserRepo, f.RateLimitRepo)
+}
+
+func (f UsecaseFactory) NewSessionUsecase() SessionUsecase {
+	return NewSessionUsecase(f.AuthConfig, f.DB, f.AuthRepo, f.AccessLogRepo)
+}
+
+func (f UsecaseFactory) NewRoleUsecase() RoleUsecase {
+	return NewRoleUsecase(f.DB, f.RoleRepo)
+}
+
+func (f UsecaseFactory) NewRateLimitUsecase(conf config.RateLimitConfig) RateLimitUsecase {
+	if conf.Store == config.RateLimitStoreDatabase {
+		return NewDBRateLimitUsecase(f.DB, f.RateLimitRepo)
+	}
+	return NewMemoryRateLimitUsecase()
 }
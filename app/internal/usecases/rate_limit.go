@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Siroshun09/serrors"
+	"github.com/okocraft/auth-service/internal/domain"
+	"github.com/okocraft/auth-service/internal/repositories"
+	"github.com/okocraft/auth-service/internal/repositories/database"
+)
+
+// memoryRateLimitSweepInterval is the interval to forget the buckets that became full again.
+const memoryRateLimitSweepInterval = time.Minute
+
+type RateLimitUsecase interface {
+	// Take takes a token from the bucket of the key.
+	Take(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitResult, error)
+}
+
+// NewMemoryRateLimitUsecase returns the RateLimitUsecase that keeps the buckets in memory.
+// The buckets are not shared between instances.
+func NewMemoryRateLimitUsecase() RateLimitUsecase {
+	return &memoryRateLimitUsecase{
+		buckets: map[string]memoryRateLimitBucket{},
+	}
+}
+
+type memoryRateLimitBucket struct {
+	bucket    domain.RateLimitBucket
+	expiresAt time.Time
+}
+
+type memoryRateLimitUsecase struct {
+	mu        sync.Mutex
+	buckets   map[string]memoryRateLimitBucket
+	lastSwept time.Time
+}
+
+func (u *memoryRateLimitUsecase) Take(_ context.Context, key string, limit domain.RateLimit) (domain.RateLimitResult, error) {
+	now := time.Now()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if memoryRateLimitSweepInterval <= now.Sub(u.lastSwept) {
+		for k, b := range u.buckets {
+			if !now.Before(b.expiresAt) {
+				delete(u.buckets, k)
+			}
+		}
+		u.lastSwept = now
+	}
+
+	current, ok := u.buckets[key]
+	if !ok {
+		current.bucket = domain.NewRateLimitBucket(limit, now)
+	}
+
+	bucket, result := current.bucket.Take(limit, now)
+	u.buckets[key] = memoryRateLimitBucket{bucket: bucket, expiresAt: bucket.ExpiresAt(limit)}
+	return result, nil
+}
+
+// NewDBRateLimitUsecase returns the RateLimitUsecase that keeps the buckets in the database.
+// The buckets are shared between instances.
+func NewDBRateLimitUsecase(db database.DB, repo repositories.RateLimitRepository) RateLimitUsecase {
+	return &dbRateLimitUsecase{
+		db:   db,
+		repo: repo,
+	}
+}
+
+type dbRateLimitUsecase struct {
+	db   database.DB
+	repo repositories.RateLimitRepository
+}
+
+func (u dbRateLimitUsecase) Take(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitResult, error) {
+	now := time.Now()
+	initial := domain.NewRateLimitBucket(limit, now)
+
+	var result domain.RateLimitResult
+	err := u.db.WithTx(ctx, func(ctx context.Context, tx database.Connection) error {
+		current, err := u.repo.GetOrCreateRateLimitBucketForUpdate(ctx, tx, key, initial, initial.ExpiresAt(limit))
+		if err != nil {
+			return serrors.WithStackTrace(err)
+		}
+
+		var bucket domain.RateLimitBucket
+		bucket, result = current.Take(limit, now)
+
+		err = u.repo.SaveRateLimitBucket(ctx, tx, key, bucket, bucket.ExpiresAt(limit))
+		if err != nil {
+			return serrors.WithStackTrace(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.RateLimitResult{}, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,174 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/Siroshun09/serrors"
+	"github.com/okocraft/auth-service/internal/domain"
+	"github.com/okocraft/auth-service/internal/repositories"
+	"github.com/okocraft/auth-service/internal/repositories/database"
+	"github.com/okocraft/authlib/user"
+)
+
+// RoleUsecase manages the roles and the permissions embedded in access tokens.
+//
+// The changes are reflected in the access tokens issued after them, so a user keeps the previous roles until the current access token expires.
+type RoleUsecase interface {
+	GetRoles(ctx context.Context) ([]domain.Role, error)
+	CreateRole(ctx context.Context, name string, permissions []string) error
+	DeleteRole(ctx context.Context, name string) error
+	GrantPermission(ctx context.Context, roleName string, permission string) error
+	RevokePermission(ctx context.Context, roleName string, permission string) error
+	AssignRole(ctx context.Context, userID user.ID, roleName string) error
+	UnassignRole(ctx context.Context, userID user.ID, roleName string) error
+	GetAuthorization(ctx context.Context, userID user.ID) (domain.Authorization, error)
+}
+
+func NewRoleUsecase(db database.DB, repo repositories.RoleRepository) RoleUsecase {
+	return &roleUsecase{
+		db:   db,
+		repo: repo,
+	}
+}
+
+type roleUsecase struct {
+	db   database.DB
+	repo repositories.RoleRepository
+}
+
+func (u roleUsecase) GetRoles(ctx context.Context) ([]domain.Role, error) {
+	roles, err := u.repo.GetRoles(ctx, u.db.Conn())
+	if err != nil {
+		return nil, serrors.WithStackTrace(err)
+	}
+	return roles, nil
+}
+
+// CreateRole creates the role if not exists and grants the permissions to it.
+func (u roleUsecase) CreateRole(ctx context.Context, name string, permissions []string) error {
+	if err := domain.ValidateRoleName(name); err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	for _, permission := range permissions {
+		if err := domain.ValidatePermission(permission); err != nil {
+			return serrors.WithStackTrace(err)
+		}
+	}
+
+	err := u.db.WithTx(ctx, func(ctx context.Context, tx database.Connection) error {
+		now := time.Now()
+
+		roleID, err := u.repo.UpsertRoleByName(ctx, tx, name, now)
+		if err != nil {
+			return serrors.WithStackTrace(err)
+		}
+
+		for _, permission := range permissions {
+			err = u.repo.SavePermissionForRole(ctx, tx, roleID, permission, now)
+			if err != nil {
+				return serrors.WithStackTrace(err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	return nil
+}
+
+func (u roleUsecase) DeleteRole(ctx context.Context, name string) error {
+	conn := u.db.Conn()
+
+	roleID, err := u.repo.GetRoleIDByName(ctx, conn, name)
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	err = u.repo.DeleteRole(ctx, conn, roleID)
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	return nil
+}
+
+func (u roleUsecase) GrantPermission(ctx context.Context, roleName string, permission string) error {
+	if err := domain.ValidatePermission(permission); err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	conn := u.db.Conn()
+
+	roleID, err := u.repo.GetRoleIDByName(ctx, conn, roleName)
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	err = u.repo.SavePermissionForRole(ctx, conn, roleID, permission, time.Now())
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	return nil
+}
+
+func (u roleUsecase) RevokePermission(ctx context.Context, roleName string, permission string) error {
+	conn := u.db.Conn()
+
+	roleID, err := u.repo.GetRoleIDByName(ctx, conn, roleName)
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	err = u.repo.DeletePermissionFromRole(ctx, conn, roleID, permission)
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	return nil
+}
+
+func (u roleUsecase) AssignRole(ctx context.Context, userID user.ID, roleName string) error {
+	conn := u.db.Conn()
+
+	roleID, err := u.repo.GetRoleIDByName(ctx, conn, roleName)
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	err = u.repo.SaveRoleForUserID(ctx, conn, userID, roleID, time.Now())
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	return nil
+}
+
+func (u roleUsecase) UnassignRole(ctx context.Context, userID user.ID, roleName string) error {
+	conn := u.db.Conn()
+
+	roleID, err := u.repo.GetRoleIDByName(ctx, conn, roleName)
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	err = u.repo.DeleteRoleFromUserID(ctx, conn, userID, roleID)
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	return nil
+}
+
+func (u roleUsecase) GetAuthorization(ctx context.Context, userID user.ID) (domain.Authorization, error) {
+	authz, err := u.repo.GetAuthorizationByUserID(ctx, u.db.Conn(), userID)
+	if err != nil {
+		return domain.Authorization{}, serrors.WithStackTrace(err)
+	}
+	return authz, nil
+}
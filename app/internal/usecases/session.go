@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/Siroshun09/serrors"
+	"github.com/gofrs/uuid/v5"
+	"github.com/okocraft/auth-service/internal/config"
+	"github.com/okocraft/auth-service/internal/domain"
+	"github.com/okocraft/auth-service/internal/repositories"
+	"github.com/okocraft/auth-service/internal/repositories/database"
+	"github.com/okocraft/authlib/user"
+)
+
+type SessionUsecase interface {
+	GetSessions(ctx context.Context, userID user.ID) ([]domain.Session, error)
+	RevokeSession(ctx context.Context, userID user.ID, loginID uuid.UUID) error
+	RevokeOtherSessions(ctx context.Context, userID user.ID, currentLoginID uuid.UUID) ([]uuid.UUID, error)
+	RevokeAllSessions(ctx context.Context, userID user.ID) ([]uuid.UUID, error)
+}
+
+func NewSessionUsecase(conf config.AuthConfig, db database.DB, authRepo repositories.AuthRepository, accessLogRepo repositories.AccessLogRepository) SessionUsecase {
+	return &sessionUsecase{
+		conf:          conf,
+		db:            db,
+		authRepo:      authRepo,
+		accessLogRepo: accessLogRepo,
+	}
+}
+
+type sessionUsecase struct {
+	conf          config.AuthConfig
+	db            database.DB
+	authRepo      repositories.AuthRepository
+	accessLogRepo repositories.AccessLogRepository
+}
+
+func (u sessionUsecase) GetSessions(ctx context.Context, userID user.ID) ([]domain.Session, error) {
+	conn := u.db.Conn()
+
+	sessions, err := u.authRepo.GetSessionsByUserID(ctx, conn, userID, time.Now().Add(-u.conf.RefreshTokenExpireDuration))
+	if err != nil {
+		return nil, serrors.WithStackTrace(err)
+	}
+
+	accessLogs, err := u.accessLogRepo.GetLatestAccessLogsOfLogins(ctx, conn, userID)
+	if err != nil {
+		return nil, serrors.WithStackTrace(err)
+	}
+
+	latestAccessLogs := make(map[uuid.UUID]domain.AccessLog, len(accessLogs))
+	for _, accessLog := range accessLogs {
+		latestAccessLogs[accessLog.LoginID] = accessLog
+	}
+
+	for i, session := range sessions {
+		if accessLog, ok := latestAccessLogs[session.LoginID]; ok {
+			sessions[i].IP = accessLog.IP
+			sessions[i].UserAgent = accessLog.UserAgent
+		}
+	}
+
+	return sessions, nil
+}
+
+func (u sessionUsecase) RevokeSession(ctx context.Context, userID user.ID, loginID uuid.UUID) error {
+	err := u.db.WithTx(ctx, func(ctx context.Context, tx database.Connection) error {
+		// prevents revoking the sessions of other users
+		found, err := u.authRepo.ExistsLoginByUserID(ctx, tx, userID, loginID)
+		if err != nil {
+			return serrors.WithStackTrace(err)
+		} else if !found {
+			return domain.SessionNotFoundError
+		}
+
+		return u.deleteTokensByLoginID(ctx, tx, loginID)
+	})
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	return nil
+}
+
+func (u sessionUsecase) RevokeOtherSessions(ctx context.Context, userID user.ID, currentLoginID uuid.UUID) ([]uuid.UUID, error) {
+	var revoked []uuid.UUID
+	err := u.db.WithTx(ctx, func(ctx context.Context, tx database.Connection) error {
+		sessions, err := u.authRepo.GetSessionsByUserID(ctx, tx, userID, time.Now().Add(-u.conf.RefreshTokenExpireDuration))
+		if err != nil {
+			return serrors.WithStackTrace(err)
+		}
+
+		for _, session := range sessions {
+			if session.LoginID == currentLoginID {
+				continue
+			}
+
+			if err := u.deleteTokensByLoginID(ctx, tx, session.LoginID); err != nil {
+				return err
+			}
+			revoked = append(revoked, session.LoginID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, serrors.WithStackTrace(err)
+	}
+
+	return revoked, nil
+}
+
+func (u sessionUsecase) RevokeAllSessions(ctx context.Context, userID user.ID) ([]uuid.UUID, error) {
+	return u.RevokeOtherSessions(ctx, userID, uuid.Nil)
+}
+
+func (u sessionUsecase) deleteTokensByLoginID(ctx context.Context, tx database.Connection, loginID uuid.UUID) error {
+	err := u.authRepo.DeleteAccessTokensByLoginID(ctx, tx, loginID)
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	err = u.authRepo.DeleteRefreshTokensByLoginID(ctx, tx, loginID)
+	if err != nil {
+		return serrors.WithStackTrace(err)
+	}
+
+	return nil
+}
@@ -2,8 +2,11 @@ package usecases
 
 import (
 	"context"
+	"slices"
 	"time"
 
+	"github.com/gofrs/uuid/v5"
+	"github.com/okocraft/auth-service/internal/config"
 	"github.com/okocraft/auth-service/internal/domain"
 	"github.com/okocraft/auth-service/internal/repositories"
 	"github.com/okocraft/auth-service/internal/repositories/database"
@@ -11,54 +14,238 @@ import (
 )
 
 type UserUsecase interface {
-	GetUserIDBySub(ctx context.Context, sub string) (user.ID, error)
-	SaveSubByLoginKey(ctx context.Context, loginKey domain.LoginKey, sub string) (user.ID, error)
+	GetOrCreateUserByUUID(ctx context.Context, userUUID uuid.UUID) (user.ID, error)
+	GetUserIDByUUID(ctx context.Context, userUUID uuid.UUID) (user.ID, error)
+	GetUserIDBySub(ctx context.Context, provider string, sub string) (user.ID, error)
+	// VerifyLoginKey returns the ID of the user who owns the login key.
+	// The ID is also returned with domain.LoginKeyExpiredError and domain.AccountSuspendedError so that the attempt can be attributed to the user.
+	VerifyLoginKey(ctx context.Context, loginKey domain.LoginKey) (user.ID, error)
+	// SaveSubByLoginKey links the subject to the user who owns the login key and consumes the key.
+	// The ID of the user is returned even if the error is returned after the login key is resolved.
+	SaveSubByLoginKey(ctx context.Context, loginKey domain.LoginKey, provider string, sub string) (user.ID, error)
+	GetIdentities(ctx context.Context, userID user.ID) ([]domain.Identity, error)
+	GetProfile(ctx context.Context, userID user.ID) (domain.UserProfile, error)
+	AddIdentity(ctx context.Context, userID user.ID, provider string, sub string) error
+	UnlinkIdentity(ctx context.Context, userID user.ID, provider string, sub string) error
+	UpdateStatus(ctx context.Context, userID user.ID, status domain.UserStatus) ([]uuid.UUID, error)
 }
 
-func NewUserUsecase(db database.DB, repo repositories.UserRepository) UserUsecase {
+func NewUserUsecase(conf config.AuthConfig, db database.DB, repo repositories.UserRepository, authRepo repositories.AuthRepository) UserUsecase {
 	return &userUsecase{
-		db:   db,
-		repo: repo,
+		conf:     conf,
+		db:       db,
+		repo:     repo,
+		authRepo: authRepo,
 	}
 }
 
 type userUsecase struct {
-	db   database.DB
-	repo repositories.UserRepository
+	conf     config.AuthConfig
+	db       database.DB
+	repo     repositories.UserRepository
+	authRepo repositories.AuthRepository
 }
 
-func (u userUsecase) GetUserIDBySub(ctx context.Context, sub string) (user.ID, error) {
-	id, err := u.repo.GetUserIDBySub(ctx, u.db.Conn(), sub)
+func (u userUsecase) GetOrCreateUserByUUID(ctx context.Context, userUUID uuid.UUID) (user.ID, error) {
+	id, err := u.repo.UpsertUserByUUID(ctx, u.db.Conn(), userUUID, time.Now())
 	if err != nil {
 		return 0, err
 	}
 	return id, nil
 }
 
-func (u userUsecase) SaveSubByLoginKey(ctx context.Context, loginKey domain.LoginKey, sub string) (user.ID, error) {
+func (u userUsecase) GetUserIDByUUID(ctx context.Context, userUUID uuid.UUID) (user.ID, error) {
+	id, err := u.repo.GetUserIDByUUID(ctx, u.db.Conn(), userUUID)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (u userUsecase) GetUserIDBySub(ctx context.Context, provider string, sub string) (user.ID, error) {
+	id, err := u.repo.GetUserIDBySub(ctx, u.db.Conn(), provider, sub)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (u userUsecase) VerifyLoginKey(ctx context.Context, loginKey domain.LoginKey) (user.ID, error) {
+	conn := u.db.Conn()
+
+	id, createdAt, err := u.repo.GetUserIDByLoginKey(ctx, conn, loginKey)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	if u.isLoginKeyExpired(createdAt, now) {
+		return id, domain.LoginKeyExpiredError
+	}
+
+	usr, err := u.repo.GetUserByID(ctx, conn, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, usr.Status.Check(now)
+}
+
+func (u userUsecase) SaveSubByLoginKey(ctx context.Context, loginKey domain.LoginKey, provider string, sub string) (user.ID, error) {
 	var result user.ID
 	err := u.db.WithTx(ctx, func(ctx context.Context, tx database.Connection) error {
-		id, err := u.repo.GetUserIDByLoginKey(ctx, tx, loginKey)
+		id, createdAt, err := u.repo.GetUserIDByLoginKey(ctx, tx, loginKey)
 		if err != nil {
 			return err
 		}
+		result = id
+
+		if u.isLoginKeyExpired(createdAt, time.Now()) {
+			return domain.LoginKeyExpiredError
+		}
 
-		err = u.repo.DeleteLoginKeyByUserID(ctx, tx, id)
+		usr, err := u.repo.GetUserByIDForUpdate(ctx, tx, id)
 		if err != nil {
 			return err
 		}
 
-		err = u.repo.SaveUserSub(ctx, tx, id, sub, time.Now())
+		if err := usr.Status.Check(time.Now()); err != nil {
+			return err
+		}
+
+		// the key is consumed by deleting it, so that only one of the concurrent callbacks with the same key can link the identity
+		err = u.repo.DeleteLoginKey(ctx, tx, loginKey)
 		if err != nil {
 			return err
 		}
 
-		result = id
-		return nil
+		return u.repo.SaveUserSub(ctx, tx, id, provider, sub, time.Now())
 	})
 	if err != nil {
-		return 0, err
+		return result, err
 	}
 
 	return result, nil
 }
+
+func (u userUsecase) isLoginKeyExpired(createdAt time.Time, now time.Time) bool {
+	return !now.Before(createdAt.Add(u.conf.LoginKeyExpireDuration))
+}
+
+func (u userUsecase) GetIdentities(ctx context.Context, userID user.ID) ([]domain.Identity, error) {
+	identities, err := u.repo.GetIdentitiesByUserID(ctx, u.db.Conn(), userID)
+	if err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
+
+func (u userUsecase) GetProfile(ctx context.Context, userID user.ID) (domain.UserProfile, error) {
+	conn := u.db.Conn()
+
+	usr, err := u.repo.GetUserByID(ctx, conn, userID)
+	if err != nil {
+		return domain.UserProfile{}, err
+	}
+
+	identities, err := u.repo.GetIdentitiesByUserID(ctx, conn, userID)
+	if err != nil {
+		return domain.UserProfile{}, err
+	}
+
+	return domain.UserProfile{
+		User:       usr,
+		Identities: identities,
+	}, nil
+}
+
+func (u userUsecase) AddIdentity(ctx context.Context, userID user.ID, provider string, sub string) error {
+	err := u.repo.SaveUserSub(ctx, u.db.Conn(), userID, provider, sub, time.Now())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u userUsecase) UnlinkIdentity(ctx context.Context, userID user.ID, provider string, sub string) error {
+	err := u.db.WithTx(ctx, func(ctx context.Context, tx database.Connection) error {
+		identities, err := u.repo.GetIdentitiesByUserIDForUpdate(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		found := slices.ContainsFunc(identities, func(identity domain.Identity) bool {
+			return identity.Provider == provider && identity.Subject == sub
+		})
+		if !found {
+			return domain.IdentityNotFoundError
+		} else if len(identities) == 1 {
+			return domain.LastIdentityUnlinkError
+		}
+
+		return u.repo.DeleteUserSub(ctx, tx, userID, provider, sub)
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateStatus changes the status of the user.
+//
+// When the user is suspended or banned, all sessions of the user are revoked in the same transaction,
+// so the access tokens already issued are rejected immediately. The login IDs of the revoked sessions are returned.
+func (u userUsecase) UpdateStatus(ctx context.Context, userID user.ID, status domain.UserStatus) ([]uuid.UUID, error) {
+	now := time.Now()
+
+	switch status.Type {
+	case domain.UserStatusTypeActive, domain.UserStatusTypeBanned:
+		status.SuspendedUntil = time.Time{}
+	case domain.UserStatusTypeSuspended:
+		if !status.SuspendedUntil.After(now) {
+			return nil, domain.InvalidUserStatusError
+		}
+	default:
+		return nil, domain.InvalidUserStatusError
+	}
+
+	if len(status.Reason) > domain.UserStatusReasonMaxLength {
+		return nil, domain.InvalidUserStatusError
+	}
+
+	var revoked []uuid.UUID
+	err := u.db.WithTx(ctx, func(ctx context.Context, tx database.Connection) error {
+		err := u.repo.UpdateUserStatus(ctx, tx, userID, status, now)
+		if err != nil {
+			return err
+		}
+
+		if status.Check(now) == nil {
+			return nil
+		}
+
+		sessions, err := u.authRepo.GetSessionsByUserID(ctx, tx, userID, now.Add(-u.conf.RefreshTokenExpireDuration))
+		if err != nil {
+			return err
+		}
+
+		err = u.authRepo.DeleteAccessTokensByUserID(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		err = u.authRepo.DeleteRefreshTokensByUserID(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		for _, session := range sessions {
+			revoked = append(revoked, session.LoginID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revoked, nil
+}
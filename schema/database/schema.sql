@@ -1,16 +1,50 @@
 CREATE TABLE IF NOT EXISTS users
+(
+    id                INT PRIMARY KEY AUTO_INCREMENT,
+    uuid              BINARY(16)   NOT NULL UNIQUE,
+    created_at        DATETIME     NOT NULL,
+    -- 0: active, 1: suspended until suspended_until, 2: banned
+    status            TINYINT      NOT NULL DEFAULT 0,
+    suspended_until   DATETIME     NULL,
+    status_reason     VARCHAR(512) NOT NULL DEFAULT '',
+    status_updated_at DATETIME     NULL
+);
+
+CREATE TABLE IF NOT EXISTS users_sub
+(
+    id         INT PRIMARY KEY AUTO_INCREMENT,
+    user_id    INT          NOT NULL REFERENCES users (id),
+    -- rows linked before providers were introduced are Google subjects
+    provider   VARCHAR(32)  NOT NULL DEFAULT 'google',
+    sub        VARCHAR(255) NOT NULL,
+    created_at DATETIME     NOT NULL,
+    UNIQUE (provider, sub)
+);
+CREATE INDEX IF NOT EXISTS idx_users_sub_user_id ON users_sub (user_id);
+
+CREATE TABLE IF NOT EXISTS roles
 (
     id         INT PRIMARY KEY AUTO_INCREMENT,
-    uuid       BINARY(16)  NOT NULL UNIQUE,
+    name       VARCHAR(64) NOT NULL UNIQUE,
     created_at DATETIME    NOT NULL
 );
 
-CREATE TABLE IF NOT EXISTS users_sub
+CREATE TABLE IF NOT EXISTS roles_permissions
 (
-    user_id    INT PRIMARY KEY REFERENCES users (id),
-    sub        VARCHAR(255) NOT NULL UNIQUE,
-    created_at DATETIME     NOT NULL
+    role_id    INT          NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    permission VARCHAR(128) NOT NULL,
+    created_at DATETIME     NOT NULL,
+    PRIMARY KEY (role_id, permission)
+);
+
+CREATE TABLE IF NOT EXISTS users_roles
+(
+    user_id    INT      NOT NULL REFERENCES users (id),
+    role_id    INT      NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (user_id, role_id)
 );
+CREATE INDEX IF NOT EXISTS idx_users_roles_role_id ON users_roles (role_id);
 
 CREATE TABLE IF NOT EXISTS users_login_key
 (
@@ -18,15 +52,26 @@ CREATE TABLE IF NOT EXISTS users_login_key
     login_key  BIGINT   NOT NULL UNIQUE,
     created_at DATETIME NOT NULL
 );
+CREATE INDEX IF NOT EXISTS idx_users_login_key_created_at ON users_login_key (created_at);
+
+-- the state JWTs already used in the OAuth callback; the rows are kept until the states expire
+CREATE TABLE IF NOT EXISTS used_login_states
+(
+    jti        BINARY(16) PRIMARY KEY,
+    expires_at DATETIME   NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_used_login_states_expires_at ON used_login_states (expires_at);
 
 CREATE TABLE IF NOT EXISTS users_refresh_tokens
 (
-    id         BIGINT PRIMARY KEY AUTO_INCREMENT,
-    user_id    INT          NOT NULL REFERENCES users (id),
-    jti        BINARY(16)   NOT NULL UNIQUE,
-    login_id   BINARY(16)   NOT NULL,
-    created_at DATETIME     NOT NULL
+    id          BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id     INT          NOT NULL REFERENCES users (id),
+    jti         BINARY(16)   NOT NULL UNIQUE,
+    login_id    BINARY(16)   NOT NULL,
+    created_at  DATETIME     NOT NULL,
+    consumed_at DATETIME     NULL
 );
+CREATE INDEX IF NOT EXISTS idx_users_refresh_tokens_login_id ON users_refresh_tokens (login_id);
 CREATE INDEX IF NOT EXISTS idx_users_refresh_tokens_created_at ON users_refresh_tokens (created_at);
 
 CREATE TABLE IF NOT EXISTS users_access_tokens
@@ -41,10 +86,29 @@ CREATE INDEX IF NOT EXISTS idx_users_access_tokens_created_at ON users_access_to
 CREATE TABLE IF NOT EXISTS users_access_logs
 (
     id          BIGINT PRIMARY KEY AUTO_INCREMENT,
-    user_id     INT          NOT NULL REFERENCES users (id),
+    -- NULL for failed attempts that cannot be attributed to a user
+    user_id     INT          NULL REFERENCES users (id),
     action_type TINYINT      NOT NULL,
-    login_id    BINARY(16)   NOT NULL UNIQUE,
+    -- 0 for successful events, otherwise the reason of the failure
+    reason      TINYINT      NOT NULL DEFAULT 0,
+    -- NULL for events that are not tied to a login, such as a denied login
+    login_id    BINARY(16)   NULL,
     ip          BINARY(16)   NOT NULL,
     user_agent  VARCHAR(512) NOT NULL,
     created_at  DATETIME     NOT NULL
 );
+CREATE INDEX IF NOT EXISTS idx_users_access_logs_login_id ON users_access_logs (login_id);
+-- rows are append-only, so ordering by id is ordering by time
+CREATE INDEX IF NOT EXISTS idx_users_access_logs_user_id_id ON users_access_logs (user_id, id);
+CREATE INDEX IF NOT EXISTS idx_users_access_logs_ip_id ON users_access_logs (ip, id);
+
+CREATE TABLE IF NOT EXISTS rate_limit_buckets
+(
+    bucket_key   VARCHAR(255) PRIMARY KEY,
+    tokens       DOUBLE       NOT NULL,
+    updated_at   DATETIME(3)  NOT NULL,
+    locked_until DATETIME(3)  NULL,
+    -- the bucket is full and unlocked again after this time, so the row can be deleted
+    expires_at   DATETIME(3)  NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_expires_at ON rate_limit_buckets (expires_at);